@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vinceglb/releasekit-ios/cli/internal/wizard"
+)
+
+func newApplyCmd() *cobra.Command {
+	var opts wizard.ApplyOptions
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Converge the repository to the committed config",
+		Long: "Converge the repository to the committed config file.\n\n" +
+			"Prints a plan, then sets missing GitHub secrets, creates or updates the\n" +
+			"ASC_APP_ID/ASC_TEAM_ID/BUNDLE_ID variables and updates the ReleaseKit steps\n" +
+			"of the release workflow.\n" +
+			"Secret values come from flags or RELEASEKIT_* env vars and are only needed\n" +
+			"for secrets that are not set on the repository yet.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := applyEnvDefaults(cmd.Flags()); err != nil {
+				return err
+			}
+			return wizard.Apply(cmd.OutOrStdout(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.ConfigPath, "config", wizard.DefaultConfigPath, "Path to the project config file")
+	flags.BoolVar(&opts.DryRun, "dry-run", false, "Print the planned changes without making them")
+	flags.StringVar(&opts.GitHubRepo, "repo", "", "GitHub repository (owner/repo); overrides the config")
+	flags.StringVar(&opts.ASCKeyID, "asc-key-id", "", "App Store Connect API key ID")
+	flags.StringVar(&opts.ASCIssuerID, "asc-issuer-id", "", "App Store Connect issuer ID")
+	flags.StringVar(&opts.P8Path, "p8-path", "", "Path to AuthKey_XXXXXX.p8")
+	flags.StringVar(&opts.P8B64, "p8-b64", "", "Base64-encoded .p8 content")
+
+	return cmd
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vinceglb/releasekit-ios/cli/internal/wizard"
+)
+
+func newCheckCmd() *cobra.Command {
+	var opts wizard.Options
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Audit the release setup without changing anything",
+		Long: "Audit the release setup of the current repository without changing anything.\n\n" +
+			"Reports whether the release workflow, GitHub secrets and variables, Xcode\n" +
+			"workspace, scheme and team ID are in place. Exits non-zero when a check fails.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := applyEnvDefaults(cmd.Flags()); err != nil {
+				return err
+			}
+			return wizard.Check(cmd.OutOrStdout(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Workspace, "workspace", "", "Xcode workspace path (default: read from the workflow or detected)")
+	flags.StringVar(&opts.Project, "project", "", "Xcode project path, for apps without a workspace")
+	flags.StringVar(&opts.Scheme, "scheme", "", "Xcode scheme (default: read from the workflow or detected)")
+	flags.StringVar(&opts.TeamID, "team-id", "", "Expected Apple Team ID (default: ASC_TEAM_ID variable)")
+	flags.StringVar(&opts.GitHubRepo, "repo", "", "GitHub repository (owner/repo)")
+
+	return cmd
+}
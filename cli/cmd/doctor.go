@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vinceglb/releasekit-ios/cli/internal/wizard"
+)
+
+func newDoctorCmd() *cobra.Command {
+	var opts wizard.Options
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the local setup and App Store Connect access",
+		Long: "Diagnose the local setup and App Store Connect access.\n\n" +
+			"Checks the asc, xcodebuild and gh tools, validates the .p8 key, confirms the\n" +
+			"ASC credentials and that the bundle ID exists. Each problem comes with a hint.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := applyEnvDefaults(cmd.Flags()); err != nil {
+				return err
+			}
+			return wizard.Doctor(cmd.OutOrStdout(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.ASCKeyID, "asc-key-id", "", "App Store Connect API key ID")
+	flags.StringVar(&opts.ASCIssuerID, "asc-issuer-id", "", "App Store Connect issuer ID")
+	flags.StringVar(&opts.P8Path, "p8-path", "", "Path to AuthKey_XXXXXX.p8")
+	flags.StringVar(&opts.P8B64, "p8-b64", "", "Base64-encoded .p8 content")
+	flags.StringVar(&opts.BundleID, "bundle-id", "", "Bundle ID expected in App Store Connect (default: from "+wizard.DefaultConfigPath+")")
+
+	return cmd
+}
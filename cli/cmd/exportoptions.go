@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vinceglb/releasekit-ios/cli/internal/wizard"
+)
+
+func newExportOptionsCmd() *cobra.Command {
+	var opts wizard.ExportOptionsCommandOptions
+
+	cmd := &cobra.Command{
+		Use:   "export-options",
+		Short: "Render ExportOptions.plist from the project config",
+		Long: "Render the ExportOptions.plist used by xcodebuild -exportArchive from the\n" +
+			"export_options section of the project config, after validating it.\n\n" +
+			"Writes to --output, else the config's export_options_path, else stdout.\n" +
+			"Pass the file to the archive action with its export_options_plist input.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return wizard.WriteExportOptions(cmd.OutOrStdout(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.ConfigPath, "config", wizard.DefaultConfigPath, "Path to the project config file")
+	flags.StringVarP(&opts.Output, "output", "o", "", `Output path ("-" for stdout; default: export_options_path from the config)`)
+
+	return cmd
+}
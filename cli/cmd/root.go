@@ -15,6 +15,10 @@ func NewRootCmd() *cobra.Command {
 	}
 
 	rootCmd.AddCommand(newWizardCmd())
+	rootCmd.AddCommand(newCheckCmd())
+	rootCmd.AddCommand(newApplyCmd())
+	rootCmd.AddCommand(newDoctorCmd())
+	rootCmd.AddCommand(newExportOptionsCmd())
 	rootCmd.AddCommand(newVersionCmd())
 
 	return rootCmd
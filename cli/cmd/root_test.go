@@ -70,3 +70,84 @@ func TestVersionCommand(t *testing.T) {
 		t.Fatalf("expected build date output, got: %s", out.String())
 	}
 }
+
+func TestWizardEnvDefaults(t *testing.T) {
+	t.Setenv("RELEASEKIT_SCHEME", "FromEnv")
+	t.Setenv("RELEASEKIT_WORKSPACE", "Env.xcworkspace")
+	t.Setenv("RELEASEKIT_NON_INTERACTIVE", "true")
+
+	command := newWizardCmd()
+	if err := command.ParseFlags([]string{"--workspace", "Flag.xcworkspace"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	if err := applyEnvDefaults(command.Flags()); err != nil {
+		t.Fatalf("applyEnvDefaults: %v", err)
+	}
+
+	if got := command.Flags().Lookup("scheme").Value.String(); got != "FromEnv" {
+		t.Errorf("expected scheme from env, got %q", got)
+	}
+	if got := command.Flags().Lookup("workspace").Value.String(); got != "Flag.xcworkspace" {
+		t.Errorf("expected flag to take precedence over env, got %q", got)
+	}
+	if got := command.Flags().Lookup("non-interactive").Value.String(); got != "true" {
+		t.Errorf("expected non-interactive from env, got %q", got)
+	}
+}
+
+func TestWizardEnvDefaultsInvalidBool(t *testing.T) {
+	t.Setenv("RELEASEKIT_NON_INTERACTIVE", "maybe")
+
+	command := newWizardCmd()
+	if err := applyEnvDefaults(command.Flags()); err == nil {
+		t.Fatal("expected error for invalid boolean env value")
+	}
+}
+
+func TestCheckHelp(t *testing.T) {
+	command := NewRootCmd()
+	out := &bytes.Buffer{}
+	command.SetOut(out)
+	command.SetErr(&bytes.Buffer{})
+	command.SetArgs([]string{"check", "--help"})
+
+	if err := command.Execute(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !bytes.Contains(out.Bytes(), []byte("without changing anything")) {
+		t.Fatalf("expected check help output, got: %s", out.String())
+	}
+}
+
+func TestApplyHelp(t *testing.T) {
+	command := NewRootCmd()
+	out := &bytes.Buffer{}
+	command.SetOut(out)
+	command.SetErr(&bytes.Buffer{})
+	command.SetArgs([]string{"apply", "--help"})
+
+	if err := command.Execute(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !bytes.Contains(out.Bytes(), []byte("--dry-run")) {
+		t.Fatalf("expected apply help to document --dry-run, got: %s", out.String())
+	}
+}
+
+func TestDoctorHelp(t *testing.T) {
+	command := NewRootCmd()
+	out := &bytes.Buffer{}
+	command.SetOut(out)
+	command.SetErr(&bytes.Buffer{})
+	command.SetArgs([]string{"doctor", "--help"})
+
+	if err := command.Execute(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !bytes.Contains(out.Bytes(), []byte("Diagnose")) {
+		t.Fatalf("expected doctor help output, got: %s", out.String())
+	}
+}
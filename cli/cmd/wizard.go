@@ -1,17 +1,82 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/vinceglb/releasekit-ios/cli/internal/wizard"
 )
 
 func newWizardCmd() *cobra.Command {
-	return &cobra.Command{
+	var opts wizard.Options
+
+	cmd := &cobra.Command{
 		Use:   "wizard",
 		Short: "Run guided setup wizard",
-		Args:  cobra.NoArgs,
+		Long: "Run guided setup wizard.\n\n" +
+			"With --non-interactive, every value comes from flags or the matching\n" +
+			"RELEASEKIT_* environment variable (e.g. --asc-key-id / RELEASEKIT_ASC_KEY_ID)\n" +
+			"and the wizard fails with the list of missing values instead of prompting.",
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return wizard.Run(cmd.OutOrStdout())
+			if err := applyEnvDefaults(cmd.Flags()); err != nil {
+				return err
+			}
+			return wizard.Run(cmd.OutOrStdout(), opts)
 		},
 	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.NonInteractive, "non-interactive", false, "Disable prompts; read every value from flags or env vars")
+	flags.StringVar(&opts.Workspace, "workspace", "", "Xcode workspace path")
+	flags.StringVar(&opts.Project, "project", "", "Xcode project path, for apps without a workspace")
+	flags.StringVar(&opts.Scheme, "scheme", "", "Xcode scheme")
+	flags.StringVar(&opts.BundleID, "bundle-id", "", "App bundle identifier")
+	flags.StringVar(&opts.TeamID, "team-id", "", "Apple Team ID")
+	flags.StringVar(&opts.AppID, "app-id", "", "App Store Connect app ID")
+	flags.StringVar(&opts.ASCKeyID, "asc-key-id", "", "App Store Connect API key ID")
+	flags.StringVar(&opts.ASCIssuerID, "asc-issuer-id", "", "App Store Connect issuer ID")
+	flags.StringVar(&opts.P8Path, "p8-path", "", "Path to AuthKey_XXXXXX.p8")
+	flags.StringVar(&opts.P8B64, "p8-b64", "", "Base64-encoded .p8 content")
+	flags.StringVar(&opts.GitHubRepo, "repo", "", "GitHub repository (owner/repo)")
+	flags.BoolVar(&opts.SetSecrets, "set-secrets", false, "Set GitHub secrets (non-interactive mode)")
+	flags.BoolVar(&opts.SetVariables, "set-variables", false, "Set GitHub variables (non-interactive mode)")
+	flags.BoolVar(&opts.WriteWorkflow, "write-workflow", false, "Generate the release workflow (non-interactive mode)")
+	flags.BoolVar(&opts.Force, "force", false, "Overwrite an existing workflow file (non-interactive mode)")
+	flags.BoolVar(&opts.RevealSecrets, "reveal-secrets", false, "Print secret values in full in the summary")
+	flags.BoolVar(&opts.NoSaveConfig, "no-save-config", false, "Do not write "+wizard.DefaultConfigPath+" (non-interactive mode)")
+	flags.StringVar(&opts.Template, "template", "", "Workflow template to render instead of the built-in one ([[ ]] delimiters)")
+	flags.StringVar(&opts.RunnerLabel, "runner-label", "", "Runner labels for the generated workflow, comma-separated (default: "+wizard.DefaultRunnerLabel+")")
+	flags.StringVar(&opts.XcodeVersion, "xcode-version", "", "Xcode version to select on the runner, e.g. 16.2 (default: the runner's)")
+	flags.StringVar(&opts.ActionRef, "action-ref", "", "Tag, branch or commit SHA of the releasekit-ios actions (default: "+wizard.DefaultActionRef+")")
+	flags.StringVar(&opts.Configuration, "configuration", "", "Xcode build configuration (default: "+wizard.DefaultConfiguration+")")
+	flags.StringVar(&opts.XcodebuildExtraArgs, "xcodebuild-extra-args", "", "Extra arguments for xcodebuild in the archive step")
+	flags.StringVar(&opts.WorkflowLayout, "workflow-layout", "", "Workflow layout: "+wizard.LayoutTwoJobs+" or "+wizard.LayoutSingleJob+" (default: "+wizard.LayoutTwoJobs+")")
+	flags.StringVar(&opts.ArtifactName, "artifact-name", "", "Name of the artifact carrying the IPA between jobs (default: "+wizard.DefaultArtifactName+")")
+	flags.IntVar(&opts.ArtifactRetentionDays, "artifact-retention-days", 0, "Days GitHub keeps the IPA artifact, 1-90 (default: "+strconv.Itoa(wizard.DefaultArtifactRetentionDays)+")")
+
+	return cmd
+}
+
+// applyEnvDefaults fills every flag the user did not pass from its
+// RELEASEKIT_* environment variable, so flags always take precedence.
+func applyEnvDefaults(flags *pflag.FlagSet) error {
+	var err error
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed || flag.Name == "help" {
+			return
+		}
+		name := wizard.EnvVarForFlag(flag.Name)
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			return
+		}
+		if setErr := flag.Value.Set(value); setErr != nil {
+			err = fmt.Errorf("invalid %s value %q: %w", name, value, setErr)
+		}
+	})
+	return err
 }
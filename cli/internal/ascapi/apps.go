@@ -0,0 +1,27 @@
+package ascapi
+
+import (
+	"context"
+	"net/url"
+)
+
+// App is an app record from GET /v1/apps.
+type App struct {
+	ID         string        `json:"id"`
+	Attributes AppAttributes `json:"attributes"`
+}
+
+// AppAttributes holds the App fields the CLI uses.
+type AppAttributes struct {
+	Name     string `json:"name"`
+	BundleID string `json:"bundleId"`
+	SKU      string `json:"sku"`
+}
+
+// ListApps returns every app visible to the API key, following pagination.
+func (c *Client) ListApps(ctx context.Context) ([]App, error) {
+	query := url.Values{}
+	query.Set("limit", "200")
+	query.Set("fields[apps]", "name,bundleId,sku")
+	return listAll[App](ctx, c, "/v1/apps", query)
+}
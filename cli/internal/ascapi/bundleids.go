@@ -0,0 +1,34 @@
+package ascapi
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// BundleID is an explicit App ID registered under the team, from
+// GET /v1/bundleIds.
+type BundleID struct {
+	ID         string             `json:"id"`
+	Attributes BundleIDAttributes `json:"attributes"`
+}
+
+// BundleIDAttributes holds the BundleID fields the CLI uses.
+type BundleIDAttributes struct {
+	Name       string `json:"name"`
+	Identifier string `json:"identifier"`
+	Platform   string `json:"platform"`
+}
+
+// ListBundleIDs returns the registered bundle IDs matching identifiers,
+// following pagination. The API filter also matches identifiers that merely
+// start with one of the values, so callers compare Identifier exactly.
+func (c *Client) ListBundleIDs(ctx context.Context, identifiers ...string) ([]BundleID, error) {
+	query := url.Values{}
+	query.Set("limit", "200")
+	query.Set("fields[bundleIds]", "name,identifier,platform")
+	if len(identifiers) > 0 {
+		query.Set("filter[identifier]", strings.Join(identifiers, ","))
+	}
+	return listAll[BundleID](ctx, c, "/v1/bundleIds", query)
+}
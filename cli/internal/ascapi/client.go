@@ -0,0 +1,172 @@
+// Package ascapi is a minimal App Store Connect REST API client.
+package ascapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the production App Store Connect API endpoint.
+const DefaultBaseURL = "https://api.appstoreconnect.apple.com"
+
+// Client calls the App Store Connect API with a team API key.
+type Client struct {
+	keyID      string
+	issuerID   string
+	key        *ecdsa.PrivateKey
+	baseURL    string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL points the client at another server, e.g. an httptest stand-in.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) { c.baseURL = strings.TrimRight(baseURL, "/") }
+}
+
+// WithHTTPClient replaces the default HTTP client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// NewClient returns a client that signs every request with key.
+func NewClient(keyID, issuerID string, key *ecdsa.PrivateKey, opts ...Option) *Client {
+	c := &Client{
+		keyID:      keyID,
+		issuerID:   issuerID,
+		key:        key,
+		baseURL:    DefaultBaseURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// APIError is a non-2xx response decoded from the App Store Connect
+// {"errors":[...]} envelope.
+type APIError struct {
+	StatusCode int
+	Errors     []ErrorItem
+}
+
+// ErrorItem is a single entry of an API error response.
+type ErrorItem struct {
+	Status string `json:"status"`
+	Code   string `json:"code"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+func (e *APIError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("App Store Connect API error (HTTP %d)", e.StatusCode)
+	}
+	parts := make([]string, len(e.Errors))
+	for i, item := range e.Errors {
+		message := item.Detail
+		if message == "" {
+			message = item.Title
+		}
+		parts[i] = item.Code + ": " + message
+	}
+	return fmt.Sprintf("App Store Connect API error (HTTP %d): %s", e.StatusCode, strings.Join(parts, "; "))
+}
+
+// page is the common shape of paginated list responses.
+type page[T any] struct {
+	Data  []T `json:"data"`
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+// listAll fetches path and every following page linked through links.next.
+func listAll[T any](ctx context.Context, c *Client, path string, query url.Values) ([]T, error) {
+	next := c.baseURL + path
+	if len(query) > 0 {
+		next += "?" + query.Encode()
+	}
+
+	var all []T
+	for next != "" {
+		var p page[T]
+		if err := c.get(ctx, next, &p); err != nil {
+			return nil, err
+		}
+		all = append(all, p.Data...)
+		next = p.Links.Next
+		if next != "" && !c.sameOrigin(next) {
+			return nil, fmt.Errorf("refusing to follow pagination link to another server: %s", next)
+		}
+	}
+	return all, nil
+}
+
+// sameOrigin reports whether rawURL has the scheme and host of the client's
+// base URL, so that the bearer token is never sent anywhere else.
+func (c *Client) sameOrigin(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, base.Scheme) && strings.EqualFold(u.Host, base.Host)
+}
+
+// get performs an authenticated GET of rawURL and decodes the JSON body into v.
+func (c *Client) get(ctx context.Context, rawURL string, v any) error {
+	token, err := SignToken(c.keyID, c.issuerID, c.key, c.now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("App Store Connect request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read App Store Connect response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		var envelope struct {
+			Errors []ErrorItem `json:"errors"`
+		}
+		if json.Unmarshal(body, &envelope) == nil {
+			apiErr.Errors = envelope.Errors
+		}
+		return apiErr
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse App Store Connect response: %w", err)
+	}
+	return nil
+}
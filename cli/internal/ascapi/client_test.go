@@ -0,0 +1,137 @@
+package ascapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListAppsFollowsPagination(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			t.Errorf("missing bearer token")
+		}
+		if r.URL.Path != "/v1/apps" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			if r.URL.Query().Get("limit") != "200" {
+				t.Errorf("expected limit=200, got %q", r.URL.RawQuery)
+			}
+			fmt.Fprintf(w, `{"data":[{"id":"1","attributes":{"name":"App One","bundleId":"com.one"}}],"links":{"next":"%s/v1/apps?cursor=2"}}`, server.URL)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"id":"2","attributes":{"name":"App Two","bundleId":"com.two"}}],"links":{}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("KEYID12345", "issuer", testKey(t), WithBaseURL(server.URL))
+	apps, err := client.ListApps(context.Background())
+	if err != nil {
+		t.Fatalf("ListApps: %v", err)
+	}
+	if len(apps) != 2 {
+		t.Fatalf("expected 2 apps across pages, got %d", len(apps))
+	}
+	if apps[1].ID != "2" || apps[1].Attributes.BundleID != "com.two" {
+		t.Errorf("unexpected second app: %+v", apps[1])
+	}
+}
+
+func TestListAppsRejectsForeignNextLink(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"data":[{"id":"1","attributes":{"name":"App One","bundleId":"com.one"}}],"links":{"next":"https://attacker.example/v1/apps?cursor=2"}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("KEYID12345", "issuer", testKey(t), WithBaseURL(server.URL))
+	_, err := client.ListApps(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "attacker.example") {
+		t.Fatalf("expected foreign next link to be refused, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected a single request, got %d", requests)
+	}
+}
+
+func TestListAppsDecodesAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errors":[{"status":"401","code":"NOT_AUTHORIZED","title":"Authentication credentials are missing or invalid.","detail":"Provide a properly configured and signed bearer token."}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("KEYID12345", "issuer", testKey(t), WithBaseURL(server.URL))
+	_, err := client.ListApps(context.Background())
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized || len(apiErr.Errors) != 1 {
+		t.Errorf("unexpected API error: %+v", apiErr)
+	}
+	if !strings.Contains(err.Error(), "NOT_AUTHORIZED: Provide a properly configured") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestListAppsNonJSONError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient("KEYID12345", "issuer", testKey(t), WithBaseURL(server.URL))
+	_, err := client.ListApps(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "HTTP 502") {
+		t.Fatalf("expected HTTP 502 error, got %v", err)
+	}
+}
+
+func TestListAppsEmpty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("KEYID12345", "issuer", testKey(t), WithBaseURL(server.URL))
+	apps, err := client.ListApps(context.Background())
+	if err != nil {
+		t.Fatalf("ListApps: %v", err)
+	}
+	if len(apps) != 0 {
+		t.Errorf("expected no apps, got %d", len(apps))
+	}
+}
+
+func TestListBundleIDsFiltersByIdentifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/bundleIds" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("filter[identifier]"); got != "com.example.app,com.example.app.Widget" {
+			t.Errorf("unexpected filter %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":[{"id":"B1","attributes":{"name":"App","identifier":"com.example.app","platform":"IOS"}}],"links":{}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("KEYID12345", "issuer", testKey(t), WithBaseURL(server.URL))
+	ids, err := client.ListBundleIDs(context.Background(), "com.example.app", "com.example.app.Widget")
+	if err != nil {
+		t.Fatalf("ListBundleIDs: %v", err)
+	}
+	if len(ids) != 1 || ids[0].Attributes.Identifier != "com.example.app" || ids[0].Attributes.Platform != "IOS" {
+		t.Errorf("unexpected bundle IDs: %+v", ids)
+	}
+}
@@ -0,0 +1,60 @@
+package ascapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Audience is the fixed "aud" claim App Store Connect expects.
+const Audience = "appstoreconnect-v1"
+
+// TokenLifetime is how long a signed token stays valid. Apple rejects tokens
+// that live longer than 20 minutes; the minute of margin absorbs clock skew
+// and slow requests.
+const TokenLifetime = 19 * time.Minute
+
+// SignToken returns an ES256-signed JWT for the App Store Connect API.
+func SignToken(keyID, issuerID string, key *ecdsa.PrivateKey, now time.Time) (string, error) {
+	header := map[string]string{
+		"alg": "ES256",
+		"kid": keyID,
+		"typ": "JWT",
+	}
+	claims := map[string]any{
+		"iss": issuerID,
+		"iat": now.Unix(),
+		"exp": now.Add(TokenLifetime).Unix(),
+		"aud": Audience,
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	signingInput := enc.EncodeToString(headerJSON) + "." + enc.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	// JWS ES256 signatures are the fixed-size concatenation r || s.
+	size := (key.Curve.Params().BitSize + 7) / 8
+	sig := make([]byte, 2*size)
+	r.FillBytes(sig[:size])
+	s.FillBytes(sig[size:])
+
+	return signingInput + "." + enc.EncodeToString(sig), nil
+}
@@ -0,0 +1,75 @@
+package ascapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return key
+}
+
+func TestSignToken(t *testing.T) {
+	key := testKey(t)
+	now := time.Unix(1_700_000_000, 0)
+
+	token, err := SignToken("KEYID12345", "issuer-uuid", key, now)
+	if err != nil {
+		t.Fatalf("SignToken: %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected 3 JWT segments, got %d", len(parts))
+	}
+
+	var header map[string]string
+	decodeSegment(t, parts[0], &header)
+	if header["alg"] != "ES256" || header["kid"] != "KEYID12345" || header["typ"] != "JWT" {
+		t.Errorf("unexpected header: %v", header)
+	}
+
+	var claims map[string]any
+	decodeSegment(t, parts[1], &claims)
+	if claims["iss"] != "issuer-uuid" || claims["aud"] != Audience {
+		t.Errorf("unexpected claims: %v", claims)
+	}
+	if exp := int64(claims["exp"].(float64)); exp != now.Add(TokenLifetime).Unix() {
+		t.Errorf("unexpected exp: %d", exp)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(sig) != 64 {
+		t.Fatalf("expected 64-byte raw signature, got %d bytes (err %v)", len(sig), err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if !ecdsa.Verify(&key.PublicKey, digest[:], r, s) {
+		t.Error("signature does not verify with the public key")
+	}
+}
+
+func decodeSegment(t *testing.T, segment string, v any) {
+	t.Helper()
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		t.Fatalf("decode segment: %v", err)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		t.Fatalf("unmarshal segment: %v", err)
+	}
+}
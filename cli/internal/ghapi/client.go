@@ -0,0 +1,128 @@
+// Package ghapi is a minimal GitHub REST API client for Actions settings.
+package ghapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the public GitHub REST API endpoint.
+const DefaultBaseURL = "https://api.github.com"
+
+// apiVersion pins the REST API version sent with every request.
+const apiVersion = "2022-11-28"
+
+// Client calls the GitHub REST API with a personal or OAuth token.
+type Client struct {
+	token      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL points the client at another server, e.g. an httptest stand-in.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) { c.baseURL = strings.TrimRight(baseURL, "/") }
+}
+
+// WithHTTPClient replaces the default HTTP client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// NewClient returns a client that authenticates every request with token.
+func NewClient(token string, opts ...Option) *Client {
+	c := &Client{
+		token:      token,
+		baseURL:    DefaultBaseURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// APIError is a non-2xx response decoded from GitHub's {"message": ...} body.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("GitHub API error (HTTP %d)", e.StatusCode)
+	}
+	return fmt.Sprintf("GitHub API error (HTTP %d): %s", e.StatusCode, e.Message)
+}
+
+// do sends an authenticated request to path with an optional JSON body and
+// decodes the JSON response into v when v is non-nil.
+func (c *Client) do(ctx context.Context, method, path string, body, v any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("GitHub request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read GitHub response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		var envelope struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &envelope) == nil {
+			apiErr.Message = envelope.Message
+		}
+		return apiErr
+	}
+
+	if v == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, v); err != nil {
+		return fmt.Errorf("failed to parse GitHub response: %w", err)
+	}
+	return nil
+}
+
+// repoPath returns the /repos/{owner}/{repo} prefix for an "owner/repo" slug.
+func repoPath(repoSlug string) (string, error) {
+	owner, repo, ok := strings.Cut(repoSlug, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", fmt.Errorf("invalid repository %q (expected owner/repo)", repoSlug)
+	}
+	return "/repos/" + owner + "/" + repo, nil
+}
@@ -0,0 +1,185 @@
+package ghapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestSetRepoSecretSealsValue(t *testing.T) {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/app/actions/secrets/public-key":
+			fmt.Fprintf(w, `{"key_id":"568250167242549743","key":"%s"}`, base64.StdEncoding.EncodeToString(publicKey[:]))
+		case r.Method == http.MethodPut && r.URL.Path == "/repos/acme/app/actions/secrets/ASC_KEY_ID":
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	key, err := client.RepoPublicKey(context.Background(), "acme/app")
+	if err != nil {
+		t.Fatalf("RepoPublicKey: %v", err)
+	}
+	if err := client.SetRepoSecret(context.Background(), "acme/app", key, "ASC_KEY_ID", "KEYID12345"); err != nil {
+		t.Fatalf("SetRepoSecret: %v", err)
+	}
+
+	if got["key_id"] != "568250167242549743" {
+		t.Errorf("unexpected key_id %q", got["key_id"])
+	}
+	sealed, err := base64.StdEncoding.DecodeString(got["encrypted_value"])
+	if err != nil {
+		t.Fatalf("encrypted_value is not base64: %v", err)
+	}
+	plain, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+	if !ok {
+		t.Fatal("sealed box could not be opened with the repository key")
+	}
+	if string(plain) != "KEYID12345" {
+		t.Errorf("expected decrypted value KEYID12345, got %q", plain)
+	}
+}
+
+func TestClientDecodesAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found","documentation_url":"https://docs.github.com"}`)
+	}))
+	defer server.Close()
+
+	_, err := NewClient("t", WithBaseURL(server.URL)).RepoPublicKey(context.Background(), "acme/app")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "Not Found" {
+		t.Errorf("unexpected error: %+v", apiErr)
+	}
+}
+
+func TestRepoPathRejectsInvalidSlug(t *testing.T) {
+	for _, slug := range []string{"", "acme", "acme/", "/app", "acme/app/extra"} {
+		if _, err := repoPath(slug); err == nil {
+			t.Errorf("expected error for %q", slug)
+		}
+	}
+}
+
+func TestSealSecretRejectsBadKey(t *testing.T) {
+	if _, err := SealSecret(base64.StdEncoding.EncodeToString([]byte("short")), "value"); err == nil {
+		t.Fatal("expected error for short public key")
+	}
+}
+
+func TestSetRepoVariableCreatesWhenMissing(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodPatch:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Not Found"}`)
+		case http.MethodPost:
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["name"] != "BUNDLE_ID" || body["value"] != "com.example.app" {
+				t.Errorf("unexpected body %v (%v)", body, err)
+			}
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("t", WithBaseURL(server.URL))
+	if err := client.SetRepoVariable(context.Background(), "acme/app", "BUNDLE_ID", "com.example.app"); err != nil {
+		t.Fatalf("SetRepoVariable: %v", err)
+	}
+	want := []string{"PATCH /repos/acme/app/actions/variables/BUNDLE_ID", "POST /repos/acme/app/actions/variables"}
+	if fmt.Sprint(requests) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, requests)
+	}
+}
+
+func TestSetRepoVariableUpdatesExisting(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient("t", WithBaseURL(server.URL))
+	if err := client.SetRepoVariable(context.Background(), "acme/app", "ASC_APP_ID", "1"); err != nil {
+		t.Fatalf("SetRepoVariable: %v", err)
+	}
+	if len(requests) != 1 || requests[0] != http.MethodPatch {
+		t.Errorf("expected a single PATCH, got %v", requests)
+	}
+}
+
+func TestListRepoSecretsFollowsPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/app/actions/secrets" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"total_count":2,"secrets":[{"name":"ASC_KEY_ID"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"total_count":2,"secrets":[{"name":"ASC_ISSUER_ID"}]}`)
+		default:
+			t.Errorf("unexpected page %q", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	names, err := NewClient("test-token", WithBaseURL(server.URL)).ListRepoSecrets(context.Background(), "acme/app")
+	if err != nil {
+		t.Fatalf("ListRepoSecrets: %v", err)
+	}
+	if fmt.Sprint(names) != "[ASC_KEY_ID ASC_ISSUER_ID]" {
+		t.Errorf("unexpected secret names %v", names)
+	}
+}
+
+func TestListRepoVariables(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/app/actions/variables" || r.URL.Query().Get("per_page") != "30" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		fmt.Fprint(w, `{"total_count":1,"variables":[{"name":"BUNDLE_ID","value":"com.example.app"}]}`)
+	}))
+	defer server.Close()
+
+	variables, err := NewClient("test-token", WithBaseURL(server.URL)).ListRepoVariables(context.Background(), "acme/app")
+	if err != nil {
+		t.Fatalf("ListRepoVariables: %v", err)
+	}
+	if len(variables) != 1 || variables[0] != (Variable{Name: "BUNDLE_ID", Value: "com.example.app"}) {
+		t.Errorf("unexpected variables %+v", variables)
+	}
+}
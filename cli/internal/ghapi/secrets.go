@@ -0,0 +1,95 @@
+package ghapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// PublicKey is the repository key that Actions secrets are encrypted with.
+type PublicKey struct {
+	KeyID string `json:"key_id"`
+	Key   string `json:"key"` // base64 Curve25519 public key
+}
+
+// RepoPublicKey fetches the Actions secrets public key of repoSlug.
+func (c *Client) RepoPublicKey(ctx context.Context, repoSlug string) (PublicKey, error) {
+	base, err := repoPath(repoSlug)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	var key PublicKey
+	if err := c.do(ctx, http.MethodGet, base+"/actions/secrets/public-key", nil, &key); err != nil {
+		return PublicKey{}, err
+	}
+	return key, nil
+}
+
+// ListRepoSecrets returns the names of the Actions secrets on repoSlug. Secret
+// values cannot be read back.
+func (c *Client) ListRepoSecrets(ctx context.Context, repoSlug string) ([]string, error) {
+	base, err := repoPath(repoSlug)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for page := 1; ; page++ {
+		var resp struct {
+			TotalCount int `json:"total_count"`
+			Secrets    []struct {
+				Name string `json:"name"`
+			} `json:"secrets"`
+		}
+		path := fmt.Sprintf("%s/actions/secrets?per_page=100&page=%d", base, page)
+		if err := c.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, err
+		}
+		for _, secret := range resp.Secrets {
+			names = append(names, secret.Name)
+		}
+		if len(resp.Secrets) == 0 || len(names) >= resp.TotalCount {
+			return names, nil
+		}
+	}
+}
+
+// SetRepoSecret encrypts value for key and creates or updates the secret name.
+func (c *Client) SetRepoSecret(ctx context.Context, repoSlug string, key PublicKey, name, value string) error {
+	base, err := repoPath(repoSlug)
+	if err != nil {
+		return err
+	}
+	encrypted, err := SealSecret(key.Key, value)
+	if err != nil {
+		return err
+	}
+	body := map[string]string{
+		"encrypted_value": encrypted,
+		"key_id":          key.KeyID,
+	}
+	return c.do(ctx, http.MethodPut, base+"/actions/secrets/"+name, body, nil)
+}
+
+// SealSecret encrypts value with a libsodium-compatible sealed box for the
+// base64 Curve25519 publicKey and returns the base64 ciphertext.
+func SealSecret(publicKey, value string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository public key: %w", err)
+	}
+	if len(raw) != 32 {
+		return "", fmt.Errorf("invalid repository public key: expected 32 bytes, got %d", len(raw))
+	}
+	var recipient [32]byte
+	copy(recipient[:], raw)
+
+	sealed, err := box.SealAnonymous(nil, []byte(value), &recipient, rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
@@ -0,0 +1,71 @@
+package ghapi
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Token sources reported by ResolveToken.
+const (
+	SourceGitHubToken = "GITHUB_TOKEN"
+	SourceGHToken     = "GH_TOKEN"
+	SourceGHConfig    = "gh config"
+)
+
+// ResolveToken returns a github.com token from GITHUB_TOKEN, GH_TOKEN or the
+// gh CLI hosts.yml, in that order, along with where it was found. Tokens that
+// gh keeps in the system keyring are not visible here.
+func ResolveToken() (token, source string) {
+	if token := strings.TrimSpace(os.Getenv("GITHUB_TOKEN")); token != "" {
+		return token, SourceGitHubToken
+	}
+	if token := strings.TrimSpace(os.Getenv("GH_TOKEN")); token != "" {
+		return token, SourceGHToken
+	}
+	if token := tokenFromGHConfig(ghConfigDir()); token != "" {
+		return token, SourceGHConfig
+	}
+	return "", ""
+}
+
+// ghConfigDir mirrors the lookup order of the gh CLI.
+func ghConfigDir() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI")
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh")
+}
+
+// tokenFromGHConfig reads the github.com oauth_token from dir/hosts.yml.
+func tokenFromGHConfig(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "hosts.yml"))
+	if err != nil {
+		return ""
+	}
+	var hosts map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}
+	if err := yaml.Unmarshal(content, &hosts); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(hosts["github.com"].OAuthToken)
+}
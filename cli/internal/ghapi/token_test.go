@@ -0,0 +1,42 @@
+package ghapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveTokenPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	hosts := "github.com:\n    user: octocat\n    oauth_token: gho_fromconfig\n    git_protocol: https\n"
+	if err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hosts), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GH_CONFIG_DIR", dir)
+
+	t.Setenv("GITHUB_TOKEN", "from-github-token")
+	t.Setenv("GH_TOKEN", "from-gh-token")
+	if token, source := ResolveToken(); token != "from-github-token" || source != SourceGitHubToken {
+		t.Errorf("expected GITHUB_TOKEN first, got %q from %q", token, source)
+	}
+
+	t.Setenv("GITHUB_TOKEN", "")
+	if token, source := ResolveToken(); token != "from-gh-token" || source != SourceGHToken {
+		t.Errorf("expected GH_TOKEN second, got %q from %q", token, source)
+	}
+
+	t.Setenv("GH_TOKEN", "")
+	if token, source := ResolveToken(); token != "gho_fromconfig" || source != SourceGHConfig {
+		t.Errorf("expected gh config token, got %q from %q", token, source)
+	}
+}
+
+func TestResolveTokenNone(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	if token, source := ResolveToken(); token != "" || source != "" {
+		t.Errorf("expected no token, got %q from %q", token, source)
+	}
+}
@@ -0,0 +1,55 @@
+package ghapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Variable is an Actions variable of a repository.
+type Variable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ListRepoVariables returns the Actions variables on repoSlug.
+func (c *Client) ListRepoVariables(ctx context.Context, repoSlug string) ([]Variable, error) {
+	base, err := repoPath(repoSlug)
+	if err != nil {
+		return nil, err
+	}
+	var variables []Variable
+	for page := 1; ; page++ {
+		var resp struct {
+			TotalCount int        `json:"total_count"`
+			Variables  []Variable `json:"variables"`
+		}
+		// The variables endpoint returns at most 30 per page.
+		path := fmt.Sprintf("%s/actions/variables?per_page=30&page=%d", base, page)
+		if err := c.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, err
+		}
+		variables = append(variables, resp.Variables...)
+		if len(resp.Variables) == 0 || len(variables) >= resp.TotalCount {
+			return variables, nil
+		}
+	}
+}
+
+// SetRepoVariable creates the Actions variable name on repoSlug, or updates
+// its value when it already exists.
+func (c *Client) SetRepoVariable(ctx context.Context, repoSlug, name, value string) error {
+	base, err := repoPath(repoSlug)
+	if err != nil {
+		return err
+	}
+	body := map[string]string{"name": name, "value": value}
+
+	err = c.do(ctx, http.MethodPatch, base+"/actions/variables/"+name, body, nil)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return c.do(ctx, http.MethodPost, base+"/actions/variables", body, nil)
+	}
+	return err
+}
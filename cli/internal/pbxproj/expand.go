@@ -0,0 +1,71 @@
+package pbxproj
+
+import (
+	"strings"
+)
+
+// maxExpandDepth stops runaway recursion on self-referencing settings.
+const maxExpandDepth = 8
+
+// expand replaces $(NAME), ${NAME} and $(NAME:modifier) references using
+// lookup. The rfc1034identifier, c99extidentifier, lower and upper modifiers
+// are supported; unknown references are kept verbatim.
+func expand(value string, lookup func(string) (string, bool), depth int) string {
+	if depth > maxExpandDepth || !strings.Contains(value, "$") {
+		return value
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 >= len(value) || (value[i+1] != '(' && value[i+1] != '{') {
+			b.WriteByte(value[i])
+			continue
+		}
+		closer := byte(')')
+		if value[i+1] == '{' {
+			closer = '}'
+		}
+		end := strings.IndexByte(value[i+2:], closer)
+		if end < 0 {
+			b.WriteString(value[i:])
+			break
+		}
+		reference := value[i+2 : i+2+end]
+		name, modifier, _ := strings.Cut(reference, ":")
+		resolved, ok := lookup(name)
+		if !ok {
+			b.WriteString(value[i : i+3+end])
+		} else {
+			b.WriteString(applyModifier(expand(resolved, lookup, depth+1), modifier))
+		}
+		i += 2 + end
+	}
+	return b.String()
+}
+
+func applyModifier(value, modifier string) string {
+	switch modifier {
+	case "rfc1034identifier":
+		return mapInvalid(value, '-')
+	case "c99extidentifier":
+		return mapInvalid(value, '_')
+	case "lower":
+		return strings.ToLower(value)
+	case "upper":
+		return strings.ToUpper(value)
+	default:
+		return value
+	}
+}
+
+func mapInvalid(value string, replacement rune) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case replacement == '-' && (r == '-' || r == '.'):
+			return r
+		}
+		return replacement
+	}, value)
+}
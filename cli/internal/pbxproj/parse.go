@@ -0,0 +1,269 @@
+// Package pbxproj reads Xcode project.pbxproj files, which use the OpenStep
+// property list format, and exposes their targets, build configurations and
+// build settings.
+package pbxproj
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseValue is the result of parsing OpenStep text: a map[string]any for
+// dictionaries, a []any for arrays, or a string for everything else.
+type parseValue = any
+
+type parser struct {
+	data []byte
+	pos  int
+	line int
+}
+
+// parsePlist parses an OpenStep property list document.
+func parsePlist(data []byte) (parseValue, error) {
+	p := &parser{data: data, line: 1}
+	p.skipSpace()
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.data) {
+		return nil, p.errorf("unexpected %q after the root object", p.data[p.pos])
+	}
+	return value, nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) advance() byte {
+	c := p.data[p.pos]
+	p.pos++
+	if c == '\n' {
+		p.line++
+	}
+	return c
+}
+
+// skipSpace skips whitespace and // or /* */ comments.
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			p.advance()
+		case c == '/' && p.pos+1 < len(p.data) && p.data[p.pos+1] == '/':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+				p.advance()
+			}
+		case c == '/' && p.pos+1 < len(p.data) && p.data[p.pos+1] == '*':
+			p.advance()
+			p.advance()
+			for p.pos < len(p.data) && !(p.data[p.pos] == '*' && p.pos+1 < len(p.data) && p.data[p.pos+1] == '/') {
+				p.advance()
+			}
+			if p.pos < len(p.data) {
+				p.advance()
+				p.advance()
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) expect(c byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return p.errorf("expected %q, got end of file", c)
+	}
+	if p.data[p.pos] != c {
+		return p.errorf("expected %q, got %q", c, p.data[p.pos])
+	}
+	p.advance()
+	return nil
+}
+
+func (p *parser) value() (parseValue, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, p.errorf("unexpected end of file")
+	}
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		return p.dictionary()
+	case c == '(':
+		return p.array()
+	case c == '"' || c == '\'':
+		return p.quoted()
+	case c == '<':
+		return p.hexData()
+	case isBareChar(c):
+		return p.bare(), nil
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+func (p *parser) dictionary() (parseValue, error) {
+	p.advance() // {
+	dict := make(map[string]any)
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unterminated dictionary")
+		}
+		if p.data[p.pos] == '}' {
+			p.advance()
+			return dict, nil
+		}
+		key, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		keyString, ok := key.(string)
+		if !ok {
+			return nil, p.errorf("dictionary key must be a string")
+		}
+		if err := p.expect('='); err != nil {
+			return nil, err
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(';'); err != nil {
+			return nil, err
+		}
+		dict[keyString] = value
+	}
+}
+
+func (p *parser) array() (parseValue, error) {
+	p.advance() // (
+	list := []any{}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unterminated array")
+		}
+		if p.data[p.pos] == ')' {
+			p.advance()
+			return list, nil
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+		p.skipSpace()
+		if p.pos < len(p.data) && p.data[p.pos] == ',' {
+			p.advance()
+			continue
+		}
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+		return list, nil
+	}
+}
+
+func (p *parser) quoted() (parseValue, error) {
+	quote := p.advance()
+	var b strings.Builder
+	for {
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unterminated string")
+		}
+		c := p.advance()
+		switch c {
+		case quote:
+			return b.String(), nil
+		case '\\':
+			if p.pos >= len(p.data) {
+				return nil, p.errorf("unterminated string")
+			}
+			if err := p.escape(&b); err != nil {
+				return nil, err
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+}
+
+func (p *parser) escape(b *strings.Builder) error {
+	c := p.advance()
+	switch c {
+	case 'n':
+		b.WriteByte('\n')
+	case 't':
+		b.WriteByte('\t')
+	case 'r':
+		b.WriteByte('\r')
+	case 'a':
+		b.WriteByte('\a')
+	case 'b':
+		b.WriteByte('\b')
+	case 'f':
+		b.WriteByte('\f')
+	case 'v':
+		b.WriteByte('\v')
+	case 'U':
+		if p.pos+4 > len(p.data) {
+			return p.errorf("truncated \\U escape")
+		}
+		code, err := strconv.ParseUint(string(p.data[p.pos:p.pos+4]), 16, 32)
+		if err != nil {
+			return p.errorf("invalid \\U escape")
+		}
+		p.pos += 4
+		b.WriteRune(rune(code))
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		digits := []byte{c}
+		for len(digits) < 3 && p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '7' {
+			digits = append(digits, p.advance())
+		}
+		code, _ := strconv.ParseUint(string(digits), 8, 8)
+		b.WriteByte(byte(code))
+	default:
+		b.WriteByte(c)
+	}
+	return nil
+}
+
+// hexData reads a <hex bytes> value and returns its hex digits as a string.
+func (p *parser) hexData() (parseValue, error) {
+	p.advance() // <
+	var b strings.Builder
+	for {
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unterminated data")
+		}
+		c := p.advance()
+		if c == '>' {
+			return b.String(), nil
+		}
+		if c != ' ' && c != '\n' && c != '\t' && c != '\r' {
+			b.WriteByte(c)
+		}
+	}
+}
+
+func (p *parser) bare() parseValue {
+	start := p.pos
+	for p.pos < len(p.data) && isBareChar(p.data[p.pos]) {
+		p.advance()
+	}
+	return string(p.data[start:p.pos])
+}
+
+func isBareChar(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.IndexByte("_$/:.-+", c) >= 0
+}
@@ -0,0 +1,294 @@
+package pbxproj
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ProductTypeApplication is the product type of an iOS or macOS app target.
+const ProductTypeApplication = "com.apple.product-type.application"
+
+// Project is a parsed project.pbxproj.
+type Project struct {
+	// Configurations are the project-level build configurations; targets
+	// inherit their settings.
+	Configurations []BuildConfiguration
+	Targets        []Target
+}
+
+// embeddedProductTypes are the product types an app embeds and that need
+// their own bundle ID and provisioning profile.
+var embeddedProductTypes = map[string]bool{
+	"com.apple.product-type.app-extension":                         true,
+	"com.apple.product-type.app-extension.messages":                true,
+	"com.apple.product-type.app-extension.messages-sticker-pack":   true,
+	"com.apple.product-type.extensionkit-extension":                true,
+	"com.apple.product-type.application.on-demand-install-capable": true, // App Clip
+	"com.apple.product-type.application.watchapp":                  true,
+	"com.apple.product-type.application.watchapp2":                 true,
+	"com.apple.product-type.application.watchapp2-container":       true,
+	"com.apple.product-type.watchkit-extension":                    true,
+	"com.apple.product-type.watchkit2-extension":                   true,
+}
+
+// IsEmbeddedProductType reports whether productType is an extension, App
+// Clip or watch app that ships inside an app.
+func IsEmbeddedProductType(productType string) bool {
+	return embeddedProductTypes[productType]
+}
+
+// Target is a native target of the project.
+type Target struct {
+	ID             string
+	Name           string
+	ProductName    string
+	ProductType    string
+	Configurations []BuildConfiguration
+	Dependencies   []string // IDs of the targets this target depends on
+}
+
+// BuildConfiguration is one XCBuildConfiguration, e.g. Debug or Release.
+type BuildConfiguration struct {
+	ID       string
+	Name     string
+	Settings map[string]string // list values are joined with spaces
+}
+
+// ParseFile reads and parses the project.pbxproj at path.
+func ParseFile(path string) (*Project, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	project, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return project, nil
+}
+
+// Parse parses project.pbxproj content.
+func Parse(content []byte) (*Project, error) {
+	root, err := parsePlist(content)
+	if err != nil {
+		return nil, err
+	}
+	doc, ok := root.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("root is not a dictionary")
+	}
+	objects, ok := doc["objects"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("missing objects dictionary")
+	}
+	rootID, _ := doc["rootObject"].(string)
+	rootObject, ok := objects[rootID].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("missing root object %q", rootID)
+	}
+
+	project := &Project{
+		Configurations: configurationList(objects, rootObject),
+	}
+	for _, targetID := range stringList(rootObject["targets"]) {
+		object, ok := objects[targetID].(map[string]any)
+		if !ok {
+			continue
+		}
+		if isa, _ := object["isa"].(string); isa != "PBXNativeTarget" {
+			continue
+		}
+		name, _ := object["name"].(string)
+		productName, _ := object["productName"].(string)
+		productType, _ := object["productType"].(string)
+		project.Targets = append(project.Targets, Target{
+			ID:             targetID,
+			Name:           name,
+			ProductName:    productName,
+			ProductType:    productType,
+			Configurations: configurationList(objects, object),
+			Dependencies:   targetDependencies(objects, object),
+		})
+	}
+	return project, nil
+}
+
+// configurationList resolves owner's buildConfigurationList.
+func configurationList(objects map[string]any, owner map[string]any) []BuildConfiguration {
+	listID, _ := owner["buildConfigurationList"].(string)
+	list, ok := objects[listID].(map[string]any)
+	if !ok {
+		return nil
+	}
+	var configurations []BuildConfiguration
+	for _, id := range stringList(list["buildConfigurations"]) {
+		object, ok := objects[id].(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := object["name"].(string)
+		settings := make(map[string]string)
+		if raw, ok := object["buildSettings"].(map[string]any); ok {
+			for key, value := range raw {
+				settings[key] = settingString(value)
+			}
+		}
+		configurations = append(configurations, BuildConfiguration{ID: id, Name: name, Settings: settings})
+	}
+	return configurations
+}
+
+// targetDependencies resolves owner's PBXTargetDependency entries to target
+// IDs. Dependencies on targets of other projects have no target and are
+// skipped.
+func targetDependencies(objects map[string]any, owner map[string]any) []string {
+	var ids []string
+	for _, id := range stringList(owner["dependencies"]) {
+		object, ok := objects[id].(map[string]any)
+		if !ok {
+			continue
+		}
+		if target, ok := object["target"].(string); ok && target != "" {
+			ids = append(ids, target)
+		}
+	}
+	return ids
+}
+
+func stringList(value any) []string {
+	items, _ := value.([]any)
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func settingString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []any:
+		return strings.Join(stringList(v), " ")
+	default:
+		return ""
+	}
+}
+
+// ApplicationTargets returns the targets that build an app.
+func (p *Project) ApplicationTargets() []Target {
+	var apps []Target
+	for _, target := range p.Targets {
+		if target.ProductType == ProductTypeApplication {
+			apps = append(apps, target)
+		}
+	}
+	return apps
+}
+
+// EmbeddedTargets returns the extensions, App Clips and watch apps that app
+// depends on, directly or through another embedded target (e.g. a watch app
+// and its extension), in dependency order.
+func (p *Project) EmbeddedTargets(app Target) []Target {
+	byID := make(map[string]Target, len(p.Targets))
+	for _, target := range p.Targets {
+		byID[target.ID] = target
+	}
+
+	var embedded []Target
+	seen := map[string]bool{app.ID: true}
+	var walk func(target Target)
+	walk = func(target Target) {
+		for _, id := range target.Dependencies {
+			dep, ok := byID[id]
+			if !ok || seen[id] || !IsEmbeddedProductType(dep.ProductType) {
+				continue
+			}
+			seen[id] = true
+			embedded = append(embedded, dep)
+			walk(dep)
+		}
+	}
+	walk(app)
+	return embedded
+}
+
+// Target returns the target called name.
+func (p *Project) Target(name string) (Target, bool) {
+	for _, target := range p.Targets {
+		if target.Name == name {
+			return target, true
+		}
+	}
+	return Target{}, false
+}
+
+// ConfigurationNames returns the sorted names of every build configuration.
+func (p *Project) ConfigurationNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	add := func(configurations []BuildConfiguration) {
+		for _, c := range configurations {
+			if !seen[c.Name] {
+				seen[c.Name] = true
+				names = append(names, c.Name)
+			}
+		}
+	}
+	add(p.Configurations)
+	for _, target := range p.Targets {
+		add(target.Configurations)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Configuration returns the target's build configuration called name.
+func (t Target) Configuration(name string) (BuildConfiguration, bool) {
+	for _, c := range t.Configurations {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return BuildConfiguration{}, false
+}
+
+// Setting returns the value of key for target in configuration. Target
+// settings override project settings, and $(VAR) references to other settings,
+// TARGET_NAME and PRODUCT_NAME are expanded. References that cannot be
+// resolved are left in place. Returns "" when the key is not set.
+func (p *Project) Setting(target Target, configuration, key string) string {
+	lookup := func(name string) (string, bool) {
+		if c, ok := target.Configuration(configuration); ok {
+			if value, ok := c.Settings[name]; ok {
+				return value, true
+			}
+		}
+		for _, c := range p.Configurations {
+			if c.Name == configuration {
+				if value, ok := c.Settings[name]; ok {
+					return value, true
+				}
+			}
+		}
+		switch name {
+		case "TARGET_NAME":
+			return target.Name, true
+		case "PRODUCT_NAME":
+			return "$(TARGET_NAME)", true
+		case "CONFIGURATION":
+			return configuration, true
+		}
+		return "", false
+	}
+
+	value, ok := lookup(key)
+	if !ok {
+		return ""
+	}
+	return expand(value, lookup, 0)
+}
@@ -0,0 +1,128 @@
+package pbxproj
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func parseFixture(t *testing.T) *Project {
+	t.Helper()
+	project, err := ParseFile(filepath.Join("testdata", "App.pbxproj"))
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	return project
+}
+
+func TestParseTargets(t *testing.T) {
+	project := parseFixture(t)
+
+	if len(project.Targets) != 2 {
+		t.Fatalf("expected 2 targets, got %d", len(project.Targets))
+	}
+	apps := project.ApplicationTargets()
+	if len(apps) != 1 || apps[0].Name != "App" {
+		t.Fatalf("expected App as the only application target, got %+v", apps)
+	}
+	if got := strings.Join(project.ConfigurationNames(), ","); got != "Debug,Release" {
+		t.Errorf("unexpected configuration names %q", got)
+	}
+}
+
+func TestEmbeddedTargets(t *testing.T) {
+	project := parseFixture(t)
+	app, _ := project.Target("App")
+
+	embedded := project.EmbeddedTargets(app)
+	if len(embedded) != 1 || embedded[0].Name != "Widget" {
+		t.Fatalf("expected Widget as the only embedded target, got %+v", embedded)
+	}
+	if widget, _ := project.Target("Widget"); len(project.EmbeddedTargets(widget)) != 0 {
+		t.Errorf("expected Widget to embed nothing")
+	}
+}
+
+func TestSettingResolution(t *testing.T) {
+	project := parseFixture(t)
+	app, _ := project.Target("App")
+	widget, _ := project.Target("Widget")
+
+	tests := []struct {
+		target        Target
+		configuration string
+		key           string
+		want          string
+	}{
+		{app, "Release", "DEVELOPMENT_TEAM", "ZYXWV98765"},
+		{app, "Debug", "DEVELOPMENT_TEAM", "ABCDE12345"}, // inherited from the project
+		{app, "Release", "CODE_SIGN_STYLE", "Manual"},
+		{app, "Debug", "CODE_SIGN_STYLE", "Automatic"},
+		{app, "Release", "PRODUCT_BUNDLE_IDENTIFIER", "com.example.app"},
+		{app, "Debug", "PRODUCT_BUNDLE_IDENTIFIER", "com.example.app.debug"},
+		{app, "Release", "MARKETING_VERSION", "1.2.0"},
+		{app, "Release", "PRODUCT_NAME", "App"},
+		{app, "Debug", "INFOPLIST_KEY_CFBundleDisplayName", `My "App"`},
+		{app, "Debug", "GCC_PREPROCESSOR_DEFINITIONS", "DEBUG=1 $(inherited)"},
+		{app, "Release", "PROVISIONING_PROFILE_SPECIFIER", "App Store Profile"},
+		{widget, "Release", "PRODUCT_BUNDLE_IDENTIFIER", "com.example.app.Widget"},
+		{widget, "Release", "MARKETING_VERSION", ""},
+		{app, "Staging", "PRODUCT_BUNDLE_IDENTIFIER", ""},
+	}
+	for _, tt := range tests {
+		if got := project.Setting(tt.target, tt.configuration, tt.key); got != tt.want {
+			t.Errorf("%s/%s %s = %q, want %q", tt.target.Name, tt.configuration, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestParseSyntax(t *testing.T) {
+	value, err := parsePlist([]byte(`// !$*UTF8*$!
+{
+	/* block comment */ a = "esc\"aped\n\U00e9\101";
+	b = ( one, "two", );
+	c = <0fbd 7777>;
+	d = {};
+	path = ../Shared/Config.xcconfig;
+}`))
+	if err != nil {
+		t.Fatalf("parsePlist: %v", err)
+	}
+	dict := value.(map[string]any)
+	if dict["a"] != "esc\"aped\néA" {
+		t.Errorf("unexpected escaped string %q", dict["a"])
+	}
+	if list := dict["b"].([]any); len(list) != 2 || list[1] != "two" {
+		t.Errorf("unexpected array %v", list)
+	}
+	if dict["c"] != "0fbd7777" {
+		t.Errorf("unexpected data %q", dict["c"])
+	}
+	if dict["path"] != "../Shared/Config.xcconfig" {
+		t.Errorf("unexpected bare string %q", dict["path"])
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"unterminated dictionary": "{ a = b;",
+		"missing semicolon":       "{ a = b }",
+		"unterminated string":     `{ a = "b; }`,
+		"trailing content":        "{ } }",
+	}
+	for name, input := range tests {
+		if _, err := parsePlist([]byte(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := Parse([]byte("{ objects = {}; }")); err == nil {
+		t.Error("expected error for missing root object")
+	}
+}
+
+func TestParseErrorReportsLine(t *testing.T) {
+	_, err := parsePlist([]byte("{\n a = b;\n c = ;\n}"))
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("expected error on line 3, got %v", err)
+	}
+}
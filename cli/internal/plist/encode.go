@@ -0,0 +1,168 @@
+// Package plist encodes values as XML property lists, the format xcodebuild
+// reads for -exportOptionsPlist.
+package plist
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const header = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+`
+
+// Marshal returns the XML property list encoding of v.
+//
+// Strings, booleans, integers and floats map to their plist types, []byte to
+// <data>, slices and arrays to <array>, and maps with string keys to <dict>
+// with sorted keys. Structs are encoded as <dict> using the field's `plist`
+// tag for the key ("-" skips the field, ",omitempty" drops zero values).
+// Nil pointers and interfaces are left out of dicts and arrays.
+func Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	e := encoder{buf: &buf}
+	if err := e.value(reflect.ValueOf(v), 0); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</plist>\n")
+	return buf.Bytes(), nil
+}
+
+type encoder struct {
+	buf *bytes.Buffer
+}
+
+func (e *encoder) line(depth int, s string) {
+	e.buf.WriteString(strings.Repeat("\t", depth))
+	e.buf.WriteString(s)
+	e.buf.WriteByte('\n')
+}
+
+func (e *encoder) text(depth int, tag, s string) {
+	var escaped bytes.Buffer
+	_ = xml.EscapeText(&escaped, []byte(s))
+	e.line(depth, "<"+tag+">"+escaped.String()+"</"+tag+">")
+}
+
+// isNil reports whether v holds no value to encode.
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
+func (e *encoder) value(v reflect.Value, depth int) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return fmt.Errorf("plist: cannot encode nil value")
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		e.text(depth, "string", v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			e.line(depth, "<true/>")
+		} else {
+			e.line(depth, "<false/>")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.text(depth, "integer", strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		e.text(depth, "integer", strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		e.text(depth, "real", strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			e.text(depth, "data", base64.StdEncoding.EncodeToString(byteSlice(v)))
+			return nil
+		}
+		e.line(depth, "<array>")
+		for i := 0; i < v.Len(); i++ {
+			if isNil(v.Index(i)) {
+				continue
+			}
+			if err := e.value(v.Index(i), depth+1); err != nil {
+				return err
+			}
+		}
+		e.line(depth, "</array>")
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("plist: map key type %s is not a string", v.Type().Key())
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		e.line(depth, "<dict>")
+		for _, key := range keys {
+			if err := e.entry(key.String(), v.MapIndex(key), depth+1); err != nil {
+				return err
+			}
+		}
+		e.line(depth, "</dict>")
+	case reflect.Struct:
+		e.line(depth, "<dict>")
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(field.Tag.Get("plist"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			fv := v.Field(i)
+			if opts == "omitempty" && fv.IsZero() {
+				continue
+			}
+			if err := e.entry(name, fv, depth+1); err != nil {
+				return err
+			}
+		}
+		e.line(depth, "</dict>")
+	default:
+		return fmt.Errorf("plist: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// entry writes a <key> and its value; nil values are skipped.
+func (e *encoder) entry(key string, v reflect.Value, depth int) error {
+	if isNil(v) {
+		return nil
+	}
+	e.text(depth, "key", key)
+	return e.value(v, depth)
+}
+
+// byteSlice returns the bytes of a []byte or [N]byte value. Arrays are copied
+// since Value.Bytes panics on arrays that are not addressable, such as one
+// held in an interface.
+func byteSlice(v reflect.Value) []byte {
+	if v.Kind() == reflect.Slice {
+		return v.Bytes()
+	}
+	b := make([]byte, v.Len())
+	for i := range b {
+		b[i] = byte(v.Index(i).Uint())
+	}
+	return b
+}
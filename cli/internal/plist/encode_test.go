@@ -0,0 +1,97 @@
+package plist
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarshalScalarsAndCollections(t *testing.T) {
+	got, err := Marshal(map[string]any{
+		"name":    "A & <B>",
+		"enabled": true,
+		"off":     false,
+		"count":   3,
+		"ratio":   1.5,
+		"blob":    []byte("hi"),
+		"list":    []string{"x", "y"},
+		"nested":  map[string]string{"k": "v"},
+		"skipped": nil,
+	})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := header + `<dict>
+	<key>blob</key>
+	<data>aGk=</data>
+	<key>count</key>
+	<integer>3</integer>
+	<key>enabled</key>
+	<true/>
+	<key>list</key>
+	<array>
+		<string>x</string>
+		<string>y</string>
+	</array>
+	<key>name</key>
+	<string>A &amp; &lt;B&gt;</string>
+	<key>nested</key>
+	<dict>
+		<key>k</key>
+		<string>v</string>
+	</dict>
+	<key>off</key>
+	<false/>
+	<key>ratio</key>
+	<real>1.5</real>
+</dict>
+</plist>
+`
+	if string(got) != want {
+		t.Errorf("Marshal() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMarshalStructTags(t *testing.T) {
+	type options struct {
+		Method   string            `plist:"method"`
+		Team     string            `plist:"teamID,omitempty"`
+		Upload   *bool             `plist:"uploadSymbols"`
+		Profiles map[string]string `plist:"provisioningProfiles,omitempty"`
+		Internal string            `plist:"-"`
+	}
+	no := false
+	got, err := Marshal(options{Method: "app-store-connect", Upload: &no, Internal: "hidden"})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	body := string(got)
+	for _, want := range []string{"<key>method</key>\n\t<string>app-store-connect</string>", "<key>uploadSymbols</key>\n\t<false/>"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in:\n%s", want, body)
+		}
+	}
+	for _, unwanted := range []string{"teamID", "provisioningProfiles", "hidden", "Internal"} {
+		if strings.Contains(body, unwanted) {
+			t.Errorf("unexpected %q in:\n%s", unwanted, body)
+		}
+	}
+}
+
+func TestMarshalByteArray(t *testing.T) {
+	got, err := Marshal(map[string]any{"k": [4]byte{'r', 'k', 'i', 't'}})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if want := "<data>cmtpdA==</data>"; !strings.Contains(string(got), want) {
+		t.Errorf("expected %q in:\n%s", want, got)
+	}
+}
+
+func TestMarshalUnsupported(t *testing.T) {
+	if _, err := Marshal(map[int]string{1: "a"}); err == nil {
+		t.Error("expected error for non-string map keys")
+	}
+	if _, err := Marshal(make(chan int)); err == nil {
+		t.Error("expected error for channels")
+	}
+}
@@ -10,6 +10,8 @@ type Theme struct {
 	mutedStyle   lipgloss.Style
 	errorStyle   lipgloss.Style
 	successStyle lipgloss.Style
+	addedStyle   lipgloss.Style
+	removedStyle lipgloss.Style
 }
 
 func NewTheme() Theme {
@@ -21,6 +23,8 @@ func NewTheme() Theme {
 		mutedStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
 		errorStyle:   lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196")),
 		successStyle: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("82")),
+		addedStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("70")),
+		removedStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("167")),
 	}
 }
 
@@ -51,3 +55,18 @@ func (t Theme) Error(value string) string {
 func (t Theme) Success(value string) string {
 	return t.successStyle.Render(value)
 }
+
+// Failure renders value in the error color without the "[error]" prefix.
+func (t Theme) Failure(value string) string {
+	return t.errorStyle.Render(value)
+}
+
+// Added renders a line added in a diff.
+func (t Theme) Added(value string) string {
+	return t.addedStyle.Render(value)
+}
+
+// Removed renders a line removed in a diff.
+func (t Theme) Removed(value string) string {
+	return t.removedStyle.Render(value)
+}
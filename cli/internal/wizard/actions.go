@@ -0,0 +1,221 @@
+package wizard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// actionPrefix starts the `uses:` value of every composite action in this
+// repository, e.g. vinceglb/releasekit-ios/actions/archive@v0.
+const actionPrefix = "vinceglb/releasekit-ios/actions/"
+
+// actionSpec is the input schema of one composite action. It mirrors
+// actions/<name>/action.yml; actions_test.go keeps the two in sync.
+type actionSpec struct {
+	inputs map[string]bool // input name -> required
+	oneOf  [][]string      // groups of inputs where exactly one must be set
+}
+
+var actionSpecs = map[string]actionSpec{
+	"archive": {
+		inputs: map[string]bool{
+			"workspace":             false,
+			"project":               false,
+			"scheme":                true,
+			"bundle_id":             true,
+			"asc_key_id":            true,
+			"asc_issuer_id":         true,
+			"asc_private_key_b64":   true,
+			"asc_team_id":           true,
+			"configuration":         false,
+			"archive_path":          false,
+			"export_path":           false,
+			"export_options_plist":  false,
+			"xcodebuild_extra_args": false,
+		},
+		oneOf: [][]string{{"workspace", "project"}},
+	},
+	"upload": {
+		inputs: map[string]bool{
+			"app_id":                 true,
+			"asc_key_id":             true,
+			"asc_issuer_id":          true,
+			"asc_private_key_b64":    true,
+			"ipa_path":               false,
+			"artifact_name":          false,
+			"artifact_download_path": false,
+			"asc_version":            false,
+			"wait_for_processing":    false,
+			"poll_interval":          false,
+		},
+		oneOf: [][]string{{"ipa_path", "artifact_name"}},
+	},
+}
+
+// WorkflowIssue is a problem with a step that uses one of this repository's
+// actions. Line is the line of the offending key or step.
+type WorkflowIssue struct {
+	Line    int
+	Message string
+}
+
+func (i WorkflowIssue) String() string {
+	return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+}
+
+// ValidateWorkflow parses a workflow and checks the `with:` keys of every step
+// that uses one of this repository's actions against the action's declared
+// inputs: unknown keys, missing required inputs, and inputs of which exactly
+// one must be set. Steps using other actions are ignored.
+func ValidateWorkflow(content string) ([]WorkflowIssue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, fmt.Errorf("invalid workflow YAML: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	var issues []WorkflowIssue
+	for _, step := range jobSteps(doc.Content[0]) {
+		issues = append(issues, validateActionStep(step)...)
+	}
+	return issues, nil
+}
+
+// workflowJob is one entry of a workflow's jobs: mapping.
+type workflowJob struct {
+	name  string
+	node  *yaml.Node
+	steps []*yaml.Node
+}
+
+// workflowJobs returns the jobs of a workflow document, in order.
+func workflowJobs(root *yaml.Node) []workflowJob {
+	jobs := mappingValue(root, "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return nil
+	}
+	var list []workflowJob
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		job := workflowJob{name: jobs.Content[i].Value, node: jobs.Content[i+1]}
+		if steps := mappingValue(job.node, "steps"); steps != nil && steps.Kind == yaml.SequenceNode {
+			job.steps = steps.Content
+		}
+		list = append(list, job)
+	}
+	return list
+}
+
+// needs returns the jobs listed under the job's needs:, which may be a single
+// name or a list.
+func (j workflowJob) needs() []string {
+	needs := mappingValue(j.node, "needs")
+	switch {
+	case needs == nil:
+		return nil
+	case needs.Kind == yaml.ScalarNode:
+		return []string{needs.Value}
+	}
+	var names []string
+	for _, item := range needs.Content {
+		names = append(names, item.Value)
+	}
+	return names
+}
+
+// jobSteps returns every step of every job in a workflow document, in order.
+func jobSteps(root *yaml.Node) []*yaml.Node {
+	var steps []*yaml.Node
+	for _, job := range workflowJobs(root) {
+		steps = append(steps, job.steps...)
+	}
+	return steps
+}
+
+// actionName returns the name of the releasekit-ios action a step uses, e.g.
+// "archive", or "" for any other step.
+func actionName(step *yaml.Node) string {
+	uses := mappingValue(step, "uses")
+	if uses == nil || !strings.HasPrefix(uses.Value, actionPrefix) {
+		return ""
+	}
+	name, _, _ := strings.Cut(strings.TrimPrefix(uses.Value, actionPrefix), "@")
+	return name
+}
+
+func validateActionStep(step *yaml.Node) []WorkflowIssue {
+	name := actionName(step)
+	if name == "" {
+		return nil
+	}
+	uses := mappingValue(step, "uses")
+	spec, ok := actionSpecs[name]
+	if !ok {
+		return []WorkflowIssue{{Line: uses.Line, Message: fmt.Sprintf("unknown action %q", uses.Value)}}
+	}
+
+	var issues []WorkflowIssue
+	given := make(map[string]bool)
+	if with := mappingValue(step, "with"); with != nil && with.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(with.Content); i += 2 {
+			key := with.Content[i]
+			given[key.Value] = true
+			if _, ok := spec.inputs[key.Value]; !ok {
+				message := fmt.Sprintf("%s action has no input %q", name, key.Value)
+				if alt := strings.ReplaceAll(key.Value, "-", "_"); alt != key.Value {
+					if _, ok := spec.inputs[alt]; ok {
+						message += fmt.Sprintf(" (did you mean %q?)", alt)
+					}
+				}
+				issues = append(issues, WorkflowIssue{Line: key.Line, Message: message})
+			}
+		}
+	}
+
+	var required []string
+	for input, isRequired := range spec.inputs {
+		if isRequired && !given[input] {
+			required = append(required, input)
+		}
+	}
+	sort.Strings(required)
+	for _, input := range required {
+		issues = append(issues, WorkflowIssue{Line: uses.Line, Message: fmt.Sprintf("%s action requires input %q", name, input)})
+	}
+	for _, group := range spec.oneOf {
+		count := 0
+		for _, input := range group {
+			if given[input] {
+				count++
+			}
+		}
+		if count != 1 {
+			issues = append(issues, WorkflowIssue{Line: uses.Line, Message: fmt.Sprintf("%s action needs exactly one of %s", name, strings.Join(group, ", "))})
+		}
+	}
+	return issues
+}
+
+// mappingValue returns the value for key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	_, value := mappingEntry(node, key)
+	return value
+}
+
+// mappingEntry returns the key and value nodes for key in a mapping node, or
+// nils.
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,92 @@
+package wizard
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestActionSpecsMatchActionYAML keeps the embedded input table in sync with
+// the composite actions in this repository.
+func TestActionSpecsMatchActionYAML(t *testing.T) {
+	for name, spec := range actionSpecs {
+		data, err := os.ReadFile(filepath.Join("..", "..", "..", "actions", name, "action.yml"))
+		if err != nil {
+			t.Fatalf("read %s action: %v", name, err)
+		}
+		var action struct {
+			Inputs map[string]struct {
+				Required bool `yaml:"required"`
+			} `yaml:"inputs"`
+		}
+		if err := yaml.Unmarshal(data, &action); err != nil {
+			t.Fatalf("parse %s action: %v", name, err)
+		}
+		for input, declared := range action.Inputs {
+			required, ok := spec.inputs[input]
+			if !ok {
+				t.Errorf("%s: input %q is missing from actionSpecs", name, input)
+			} else if required != declared.Required {
+				t.Errorf("%s: input %q required=%v in actionSpecs, %v in action.yml", name, input, required, declared.Required)
+			}
+		}
+		for input := range spec.inputs {
+			if _, ok := action.Inputs[input]; !ok {
+				t.Errorf("%s: actionSpecs lists %q, which action.yml does not declare", name, input)
+			}
+		}
+	}
+}
+
+func TestValidateWorkflowReportsInputProblems(t *testing.T) {
+	content := `jobs:
+  release:
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          anything: goes
+      - uses: vinceglb/releasekit-ios/actions/archive@v0
+        with:
+          workspace: App.xcworkspace
+          project: App.xcodeproj
+          scheme: App
+          bundle-id: com.example.app
+          asc_key_id: KEY
+          asc_issuer_id: ISSUER
+          asc_private_key_b64: KEY_B64
+          asc_team_id: TEAM
+      - uses: vinceglb/releasekit-ios/actions/upload@v0
+      - uses: vinceglb/releasekit-ios/actions/notarize@v0
+`
+	issues, err := ValidateWorkflow(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		`line 12: archive action has no input "bundle-id" (did you mean "bundle_id"?)`,
+		`line 7: archive action requires input "bundle_id"`,
+		`line 7: archive action needs exactly one of workspace, project`,
+		`line 17: upload action requires input "app_id"`,
+		`line 17: upload action requires input "asc_issuer_id"`,
+		`line 17: upload action requires input "asc_key_id"`,
+		`line 17: upload action requires input "asc_private_key_b64"`,
+		`line 17: upload action needs exactly one of ipa_path, artifact_name`,
+		`line 18: unknown action "vinceglb/releasekit-ios/actions/notarize@v0"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("issues =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateWorkflowRejectsInvalidYAML(t *testing.T) {
+	if _, err := ValidateWorkflow("jobs: ["); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
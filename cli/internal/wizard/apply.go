@@ -0,0 +1,324 @@
+package wizard
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/ghapi"
+	"github.com/vinceglb/releasekit-ios/cli/internal/term"
+)
+
+// ApplyOptions configures the apply command.
+type ApplyOptions struct {
+	ConfigPath  string
+	DryRun      bool
+	GitHubRepo  string // overrides the config "repo" key
+	ASCKeyID    string
+	ASCIssuerID string
+	P8Path      string
+	P8B64       string
+}
+
+// PlanAction is what apply will do to a single resource.
+type PlanAction string
+
+const (
+	PlanCreate PlanAction = "create"
+	PlanUpdate PlanAction = "update"
+	PlanKeep   PlanAction = "unchanged"
+)
+
+// PlanChange is one secret, variable or workflow file in an apply plan.
+type PlanChange struct {
+	Kind   string // "secret", "variable" or "workflow"
+	Name   string
+	Action PlanAction
+	Old    string // previous variable value, for display
+	Note   string // why a workflow change differs from the plain update, for display
+	value  string
+	diff   string // unified diff of a workflow update, for display
+}
+
+// Apply converges the repository to the committed config: it sets missing
+// GitHub secrets, creates or updates variables and writes the workflow. The
+// plan is always printed first; with DryRun nothing is changed.
+func Apply(out io.Writer, opts ApplyOptions) error {
+	theme := term.NewTheme()
+
+	fmt.Fprintln(out, theme.Title("ReleaseKit-iOS Apply"))
+	fmt.Fprintln(out)
+
+	configPath := opts.ConfigPath
+	if configPath == "" {
+		configPath = DefaultConfigPath
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	inputs := cfg.Inputs()
+
+	privKeyB64, err := resolvePrivateKey(opts.P8Path, opts.P8B64)
+	if err != nil {
+		return err
+	}
+	inputs.ASCKeyID = strings.TrimSpace(opts.ASCKeyID)
+	inputs.ASCIssuerID = strings.TrimSpace(opts.ASCIssuerID)
+	inputs.ASCPrivateKeyB64 = privKeyB64
+	if err := normalizeKeyInput(out, theme, &inputs.ASCPrivateKeyB64); err != nil {
+		return err
+	}
+
+	if repo := strings.TrimSpace(opts.GitHubRepo); repo != "" {
+		inputs.GitHubRepo = repo
+	}
+	if inputs.GitHubRepo == "" {
+		owner, repo, detectErr := DetectGitRepo()
+		if detectErr != nil {
+			return fmt.Errorf("GitHub repository not set (add \"repo\" to %s or pass --repo): %w", configPath, detectErr)
+		}
+		inputs.GitHubRepo = owner + "/" + repo
+	}
+
+	if !canSetGitHubSettings(commandExists("gh") && ghIsAuthenticated()) {
+		return fmt.Errorf("apply requires a GitHub token (GITHUB_TOKEN or GH_TOKEN) or an authenticated gh CLI (run: gh auth login)")
+	}
+
+	secretNames, err := ListGitHubSecretNames(inputs.GitHubRepo)
+	if err != nil {
+		return err
+	}
+	vars, err := ListGitHubVariables(inputs.GitHubRepo)
+	if err != nil {
+		return err
+	}
+	generated, err := GenerateWorkflow(inputs.WorkflowOptions())
+	if err != nil {
+		return fmt.Errorf("failed to generate workflow: %w", err)
+	}
+	var existing *string
+	if content, readErr := os.ReadFile(inputs.WorkflowPath); readErr == nil {
+		s := string(content)
+		existing = &s
+	}
+
+	plan, err := buildApplyPlan(inputs, secretNames, vars, existing, generated)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, theme.Section("Plan for "+inputs.GitHubRepo))
+	changes := printApplyPlan(out, theme, plan)
+	fmt.Fprintln(out)
+
+	if opts.DryRun {
+		fmt.Fprintln(out, theme.Muted("Dry run: no changes made."))
+		return nil
+	}
+	if changes == 0 {
+		fmt.Fprintln(out, theme.Success("Nothing to do; repository is up to date."))
+		return nil
+	}
+
+	return executeApplyPlan(out, theme, inputs, plan)
+}
+
+// buildApplyPlan compares the desired state from inputs with what exists on
+// the repository. existing is nil when the workflow file does not exist.
+func buildApplyPlan(inputs Inputs, secretNames []string, vars map[string]string, existing *string, generated string) ([]PlanChange, error) {
+	// The variables are pushed as-is, so reject malformed values before
+	// anything reaches the repository.
+	for _, err := range []error{
+		validateTeamID(inputs.TeamID),
+		validateBundleID(inputs.BundleID),
+		validateAppID(inputs.AppID),
+	} {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var plan []PlanChange
+
+	secretValues := map[string]string{
+		"ASC_KEY_ID":          inputs.ASCKeyID,
+		"ASC_ISSUER_ID":       inputs.ASCIssuerID,
+		"ASC_PRIVATE_KEY_B64": inputs.ASCPrivateKeyB64,
+	}
+	secretFlags := map[string]string{
+		"ASC_KEY_ID":          "asc-key-id",
+		"ASC_ISSUER_ID":       "asc-issuer-id",
+		"ASC_PRIVATE_KEY_B64": "p8-path",
+	}
+	var missing []string
+	for _, name := range requiredSecretNames {
+		change := PlanChange{Kind: "secret", Name: name, Action: PlanKeep}
+		if !slices.Contains(secretNames, name) {
+			if secretValues[name] == "" {
+				flag := secretFlags[name]
+				missing = append(missing, fmt.Sprintf("%s (--%s / %s)", name, flag, EnvVarForFlag(flag)))
+				continue
+			}
+			change.Action = PlanCreate
+			change.value = secretValues[name]
+		}
+		plan = append(plan, change)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("secrets missing on the repository and no value provided:\n  - %s", strings.Join(missing, "\n  - "))
+	}
+
+	varValues := map[string]string{
+		"ASC_APP_ID":  inputs.AppID,
+		"ASC_TEAM_ID": inputs.TeamID,
+		"BUNDLE_ID":   inputs.BundleID,
+	}
+	for _, name := range requiredVariableNames {
+		change := PlanChange{Kind: "variable", Name: name, Action: PlanKeep, value: varValues[name]}
+		current, ok := vars[name]
+		switch {
+		case !ok:
+			change.Action = PlanCreate
+		case current != change.value:
+			change.Action = PlanUpdate
+			change.Old = current
+		}
+		plan = append(plan, change)
+	}
+
+	workflow := PlanChange{Kind: "workflow", Name: inputs.WorkflowPath, Action: PlanKeep, value: generated}
+	switch {
+	case existing == nil:
+		workflow.Action = PlanCreate
+	case *existing != generated:
+		workflow = planWorkflowUpdate(inputs.WorkflowPath, *existing, generated)
+	}
+	plan = append(plan, workflow)
+
+	return plan, nil
+}
+
+// planWorkflowUpdate plans the update of an existing workflow the way the
+// wizard's default choice does: the ReleaseKit steps are merged in place so
+// hand edits survive, and when that is not possible the generated workflow
+// is written next to it instead of overwriting it.
+func planWorkflowUpdate(path, existing, generated string) PlanChange {
+	merged, missing, err := MergeWorkflow(existing, generated)
+	if err == nil && len(missing) == 0 {
+		change := PlanChange{Kind: "workflow", Name: path, Action: PlanKeep, value: merged}
+		if merged != existing {
+			change.Action = PlanUpdate
+			change.diff = UnifiedDiff(path, path+" (merged)", existing, merged)
+		}
+		return change
+	}
+
+	// A side file without a .yml extension is not picked up by GitHub Actions.
+	sidePath := path + ".new"
+	change := PlanChange{
+		Kind:   "workflow",
+		Name:   sidePath,
+		Action: PlanCreate,
+		value:  generated,
+		diff:   UnifiedDiff(path, sidePath, existing, generated),
+	}
+	if err != nil {
+		change.Note = fmt.Sprintf("cannot update the ReleaseKit steps of %s in place: %v", path, err)
+	} else {
+		change.Note = fmt.Sprintf("%s has no step for %s; move what you need from %s", path, strings.Join(missing, ", "), sidePath)
+	}
+	return change
+}
+
+// printApplyPlan prints the plan and returns the number of pending changes.
+func printApplyPlan(out io.Writer, theme term.Theme, plan []PlanChange) int {
+	changes := 0
+	for _, change := range plan {
+		var marker, detail string
+		switch change.Action {
+		case PlanCreate:
+			marker = theme.Success("+")
+			changes++
+		case PlanUpdate:
+			marker = theme.Success("~")
+			changes++
+		default:
+			marker = theme.Muted("=")
+		}
+		if change.Kind == "variable" && change.Action != PlanKeep {
+			detail = change.value
+			if change.Action == PlanUpdate {
+				detail = change.Old + " → " + change.value
+			}
+		}
+		line := fmt.Sprintf("%-8s %s", change.Kind, change.Name)
+		fmt.Fprintf(out, "  %s %s %s %s\n", marker, theme.Label(line), theme.Muted("("+string(change.Action)+")"), theme.Value(detail))
+		if change.Note != "" {
+			fmt.Fprintf(out, "    %s\n", theme.Muted(change.Note))
+		}
+		if change.diff != "" {
+			fmt.Fprintln(out)
+			fmt.Fprint(out, colorDiff(theme, change.diff))
+			fmt.Fprintln(out)
+		}
+	}
+	return changes
+}
+
+// executeApplyPlan makes the pending changes of plan; opts configure the
+// GitHub API client.
+func executeApplyPlan(out io.Writer, theme term.Theme, inputs Inputs, plan []PlanChange, opts ...ghapi.Option) error {
+	secrets := map[string]string{}
+	variables := map[string]string{}
+	var workflows []PlanChange
+	for _, change := range plan {
+		if change.Action == PlanKeep {
+			continue
+		}
+		switch change.Kind {
+		case "secret":
+			secrets[change.Name] = change.value
+		case "variable":
+			variables[change.Name] = change.value
+		case "workflow":
+			workflows = append(workflows, change)
+		}
+	}
+
+	failed := 0
+	report := func(kind string, names []string, errs map[string]error) {
+		for _, name := range names {
+			setErr, ok := errs[name]
+			if !ok {
+				continue
+			}
+			if setErr != nil {
+				fmt.Fprintf(out, "  %s Failed to set %s %s: %v\n", theme.Error("✗"), kind, name, setErr)
+				failed++
+			} else {
+				fmt.Fprintf(out, "  %s %s %s set\n", theme.Success("✓"), kind, name)
+			}
+		}
+	}
+	if len(secrets) > 0 {
+		report("secret", requiredSecretNames, SetGitHubSecrets(inputs.GitHubRepo, secrets, opts...))
+	}
+	if len(variables) > 0 {
+		report("variable", requiredVariableNames, SetGitHubVariables(inputs.GitHubRepo, variables, opts...))
+	}
+	for _, workflow := range workflows {
+		if err := WriteWorkflow(workflow.Name, workflow.value); err != nil {
+			return fmt.Errorf("failed to write workflow: %w", err)
+		}
+		fmt.Fprintf(out, "  %s %s written\n", theme.Success("✓"), workflow.Name)
+	}
+	fmt.Fprintln(out)
+
+	if failed > 0 {
+		return fmt.Errorf("apply failed: %d change(s) could not be made", failed)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package wizard
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/ghapi"
+	"github.com/vinceglb/releasekit-ios/cli/internal/term"
+)
+
+func applyTestInputs() Inputs {
+	return Inputs{
+		Workspace:    "App.xcworkspace",
+		Scheme:       "App",
+		BundleID:     "com.example.app",
+		TeamID:       "ABCDE12345",
+		AppID:        "123456789",
+		GitHubRepo:   "acme/app",
+		WorkflowPath: DefaultWorkflowPath(),
+	}
+}
+
+func planByName(plan []PlanChange) map[string]PlanChange {
+	byName := make(map[string]PlanChange, len(plan))
+	for _, change := range plan {
+		byName[change.Name] = change
+	}
+	return byName
+}
+
+func TestBuildApplyPlanFreshRepo(t *testing.T) {
+	inputs := applyTestInputs()
+	inputs.ASCKeyID = "KEYID12345"
+	inputs.ASCIssuerID = "00000000-0000-0000-0000-000000000000"
+	inputs.ASCPrivateKeyB64 = "cHJpdmF0ZS1rZXk="
+
+	plan, err := buildApplyPlan(inputs, nil, map[string]string{}, nil, "workflow")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan) != 7 {
+		t.Fatalf("expected 7 changes, got %d", len(plan))
+	}
+	for _, change := range plan {
+		if change.Action != PlanCreate {
+			t.Errorf("expected %s %s to be created, got %s", change.Kind, change.Name, change.Action)
+		}
+	}
+}
+
+func TestBuildApplyPlanConverged(t *testing.T) {
+	inputs := applyTestInputs()
+	existing := "workflow"
+	vars := map[string]string{"ASC_APP_ID": "123456789", "ASC_TEAM_ID": "ABCDE12345", "BUNDLE_ID": "com.example.app"}
+
+	plan, err := buildApplyPlan(inputs, requiredSecretNames, vars, &existing, "workflow")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, change := range plan {
+		if change.Action != PlanKeep {
+			t.Errorf("expected %s %s unchanged, got %s", change.Kind, change.Name, change.Action)
+		}
+	}
+}
+
+func TestBuildApplyPlanUpdates(t *testing.T) {
+	inputs := applyTestInputs()
+	existing := handEditedWorkflow
+	vars := map[string]string{"ASC_APP_ID": "123456789", "ASC_TEAM_ID": "ZZZZZ99999"}
+	generated, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcodeproj", Scheme: "App", ActionRef: "v1", Layout: LayoutSingleJob})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plan, err := buildApplyPlan(inputs, requiredSecretNames, vars, &existing, generated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byName := planByName(plan)
+	if c := byName["ASC_TEAM_ID"]; c.Action != PlanUpdate || c.Old != "ZZZZZ99999" {
+		t.Errorf("expected ASC_TEAM_ID update from ZZZZZ99999, got %+v", c)
+	}
+	if c := byName["BUNDLE_ID"]; c.Action != PlanCreate {
+		t.Errorf("expected BUNDLE_ID create, got %s", c.Action)
+	}
+	c := byName[DefaultWorkflowPath()]
+	if c.Action != PlanUpdate {
+		t.Fatalf("expected workflow update, got %s", c.Action)
+	}
+	if !strings.Contains(c.value, "Run tests") || !strings.Contains(c.value, "actions/archive@v1") {
+		t.Errorf("expected the merged workflow to keep hand edits and update the steps:\n%s", c.value)
+	}
+	if !strings.Contains(c.diff, "+        uses: vinceglb/releasekit-ios/actions/archive@v1") {
+		t.Errorf("expected the plan to carry the diff, got:\n%s", c.diff)
+	}
+}
+
+func TestBuildApplyPlanWritesSideFileWhenMergeFails(t *testing.T) {
+	inputs := applyTestInputs()
+	existing := "jobs:\n  build:\n    steps:\n      - run: make\n"
+	generated, err := GenerateWorkflow(inputs.WorkflowOptions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plan, err := buildApplyPlan(inputs, requiredSecretNames, map[string]string{}, &existing, generated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byName := planByName(plan)
+	if c, ok := byName[DefaultWorkflowPath()]; ok {
+		t.Errorf("expected the existing workflow to be left alone, got %+v", c)
+	}
+	c := byName[DefaultWorkflowPath()+".new"]
+	if c.Action != PlanCreate || c.value != generated || c.Note == "" {
+		t.Errorf("expected the generated workflow in a side file, got %+v", c)
+	}
+}
+
+func TestExecuteApplyPlanReportsInOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Chdir(t.TempDir())
+
+	inputs := applyTestInputs()
+	plan := []PlanChange{
+		{Kind: "variable", Name: "ASC_APP_ID", Action: PlanCreate, value: "123456789"},
+		{Kind: "variable", Name: "ASC_TEAM_ID", Action: PlanCreate, value: "ABCDE12345"},
+		{Kind: "variable", Name: "BUNDLE_ID", Action: PlanCreate, value: "com.example.app"},
+		{Kind: "workflow", Name: inputs.WorkflowPath, Action: PlanCreate, value: "name: Release\n"},
+	}
+	for range 5 {
+		var out bytes.Buffer
+		if err := executeApplyPlan(&out, term.NewTheme(), inputs, plan, ghapi.WithBaseURL(server.URL)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		app := strings.Index(out.String(), "ASC_APP_ID")
+		team := strings.Index(out.String(), "ASC_TEAM_ID")
+		bundle := strings.Index(out.String(), "BUNDLE_ID")
+		if app < 0 || !(app < team && team < bundle) {
+			t.Fatalf("expected variables reported in order, got:\n%s", out.String())
+		}
+	}
+	if content, err := os.ReadFile(inputs.WorkflowPath); err != nil || string(content) != "name: Release\n" {
+		t.Errorf("workflow not written: %q, %v", content, err)
+	}
+}
+
+func TestBuildApplyPlanMissingSecretValue(t *testing.T) {
+	_, err := buildApplyPlan(applyTestInputs(), []string{"ASC_KEY_ID"}, nil, nil, "workflow")
+	if err == nil {
+		t.Fatal("expected error when secret values are missing")
+	}
+	for _, want := range []string{"ASC_ISSUER_ID (--asc-issuer-id / RELEASEKIT_ASC_ISSUER_ID)", "ASC_PRIVATE_KEY_B64"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %q, got: %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "ASC_KEY_ID (") {
+		t.Errorf("ASC_KEY_ID already exists and should not be listed: %v", err)
+	}
+}
+
+func TestBuildApplyPlanRejectsInvalidIDs(t *testing.T) {
+	cases := map[string]struct {
+		edit func(*Inputs)
+		want string
+	}{
+		"team":   {func(in *Inputs) { in.TeamID = "abcde12345" }, "Apple Team ID must be uppercase"},
+		"bundle": {func(in *Inputs) { in.BundleID = "com.example.*" }, "Bundle ID must be explicit"},
+		"app":    {func(in *Inputs) { in.AppID = "com.example.app" }, "App ID must be numeric"},
+	}
+	for name, tc := range cases {
+		inputs := applyTestInputs()
+		tc.edit(&inputs)
+		_, err := buildApplyPlan(inputs, requiredSecretNames, map[string]string{}, nil, "workflow")
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected %q, got %v", name, tc.want, err)
+		}
+	}
+}
+
+func TestPrintApplyPlanHidesSecretValues(t *testing.T) {
+	plan := []PlanChange{
+		{Kind: "secret", Name: "ASC_PRIVATE_KEY_B64", Action: PlanCreate, value: "super-secret"},
+		{Kind: "variable", Name: "ASC_TEAM_ID", Action: PlanUpdate, Old: "OLD", value: "NEW"},
+		{Kind: "workflow", Name: "release.yml", Action: PlanKeep},
+	}
+	var out bytes.Buffer
+	if changes := printApplyPlan(&out, term.NewTheme(), plan); changes != 2 {
+		t.Errorf("expected 2 pending changes, got %d", changes)
+	}
+	if strings.Contains(out.String(), "super-secret") {
+		t.Errorf("secret value leaked into plan output:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "OLD → NEW") {
+		t.Errorf("expected variable diff in plan output:\n%s", out.String())
+	}
+}
@@ -1,12 +1,32 @@
 package wizard
 
 import (
+	"crypto/elliptic"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/ascapi"
 )
 
-func TestParseASCAppsOutputBareArray(t *testing.T) {
-	raw := `[{"id":"123456789","attributes":{"name":"My App","bundleId":"com.example.myapp"}}]`
-	apps, err := parseASCAppsOutput([]byte(raw))
+// withASCServer starts a stand-in server for the test and returns the option
+// that points an App Store Connect client at it.
+func withASCServer(t *testing.T, handler http.HandlerFunc) ascapi.Option {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return ascapi.WithBaseURL(server.URL)
+}
+
+func TestListASCApps(t *testing.T) {
+	server := withASCServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":"123456789","attributes":{"name":"My App","bundleId":"com.example.myapp"}}]}`)
+	})
+
+	apps, err := ListASCApps("KEYID12345", "issuer", testP8KeyB64(t, elliptic.P256()), server)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -24,26 +44,29 @@ func TestParseASCAppsOutputBareArray(t *testing.T) {
 	}
 }
 
-func TestParseASCAppsOutputDataEnvelope(t *testing.T) {
-	raw := `{"data":[{"id":"987654321","attributes":{"name":"Other App","bundleId":"com.example.other"}}]}`
-	apps, err := parseASCAppsOutput([]byte(raw))
+func TestListASCAppsMultiple(t *testing.T) {
+	server := withASCServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[
+			{"id":"1","attributes":{"name":"App One","bundleId":"com.one"}},
+			{"id":"2","attributes":{"name":"App Two","bundleId":"com.two"}}
+		]}`)
+	})
+
+	apps, err := ListASCApps("KEYID12345", "issuer", testP8KeyB64(t, elliptic.P256()), server)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(apps) != 1 {
-		t.Fatalf("expected 1 app, got %d", len(apps))
-	}
-	if apps[0].ID != "987654321" {
-		t.Errorf("expected ID 987654321, got %s", apps[0].ID)
-	}
-	if apps[0].Attributes.Name != "Other App" {
-		t.Errorf("expected name 'Other App', got %s", apps[0].Attributes.Name)
+	if len(apps) != 2 {
+		t.Fatalf("expected 2 apps, got %d", len(apps))
 	}
 }
 
-func TestParseASCAppsOutputEmptyArray(t *testing.T) {
-	raw := `[]`
-	apps, err := parseASCAppsOutput([]byte(raw))
+func TestListASCAppsEmptyData(t *testing.T) {
+	server := withASCServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+
+	apps, err := ListASCApps("KEYID12345", "issuer", testP8KeyB64(t, elliptic.P256()), server)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -52,27 +75,21 @@ func TestParseASCAppsOutputEmptyArray(t *testing.T) {
 	}
 }
 
-func TestParseASCAppsOutputEmptyData(t *testing.T) {
-	raw := `{"data":[]}`
-	apps, err := parseASCAppsOutput([]byte(raw))
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-	if len(apps) != 0 {
-		t.Errorf("expected 0 apps, got %d", len(apps))
+func TestListASCAppsUnauthorized(t *testing.T) {
+	server := withASCServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errors":[{"status":"401","code":"NOT_AUTHORIZED","title":"Authentication credentials are missing or invalid."}]}`)
+	})
+
+	_, err := ListASCApps("KEYID12345", "issuer", testP8KeyB64(t, elliptic.P256()), server)
+	if err == nil || !strings.Contains(err.Error(), "NOT_AUTHORIZED") {
+		t.Fatalf("expected NOT_AUTHORIZED error, got %v", err)
 	}
 }
 
-func TestParseASCAppsOutputMultiple(t *testing.T) {
-	raw := `[
-		{"id":"1","attributes":{"name":"App One","bundleId":"com.one"}},
-		{"id":"2","attributes":{"name":"App Two","bundleId":"com.two"}}
-	]`
-	apps, err := parseASCAppsOutput([]byte(raw))
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-	if len(apps) != 2 {
-		t.Fatalf("expected 2 apps, got %d", len(apps))
+func TestListASCAppsInvalidKey(t *testing.T) {
+	_, err := ListASCApps("KEYID12345", "issuer", base64.StdEncoding.EncodeToString([]byte("private-key")))
+	if err == nil || !strings.Contains(err.Error(), "invalid private key") {
+		t.Fatalf("expected invalid private key error, got %v", err)
 	}
 }
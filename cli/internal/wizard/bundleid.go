@@ -0,0 +1,58 @@
+package wizard
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// bundleIDMismatch cross-checks inputs.BundleID against what the Xcode project
+// builds, so a wrong app fails here instead of in archive.sh. It prefers the
+// scheme's resolved build settings; without xcodebuild it reads the target the
+// scheme file archives and that target's settings in the pbxproj. Returns ""
+// when the IDs agree or the scheme's bundle ID could not be resolved.
+func bundleIDMismatch(inputs Inputs) string {
+	configuration := inputs.Configuration
+	if configuration == "" {
+		configuration = DefaultConfiguration
+	}
+
+	if built := DetectSchemeBundleID(inputs.Workspace, inputs.Scheme, configuration); built != "" {
+		if built == inputs.BundleID {
+			return ""
+		}
+		return fmt.Sprintf("scheme %q builds %s in %s, but the App Store Connect app is %s",
+			inputs.Scheme, built, configuration, inputs.BundleID)
+	}
+
+	target, built := schemeTargetBundleID(inputs.Workspace, inputs.Scheme, configuration)
+	if built == "" || built == inputs.BundleID {
+		return ""
+	}
+	return fmt.Sprintf("scheme %q archives target %s, whose PRODUCT_BUNDLE_IDENTIFIER in %s is %s, but the App Store Connect app is %s",
+		inputs.Scheme, target, configuration, built, inputs.BundleID)
+}
+
+// schemeTargetBundleID returns the target the scheme file archives and its
+// literal PRODUCT_BUNDLE_IDENTIFIER in configuration, or "" when either
+// cannot be resolved.
+func schemeTargetBundleID(workspacePath, schemeName, configuration string) (target, bundleID string) {
+	scheme, ok := findSchemeFile(workspacePath, schemeName)
+	if !ok || scheme.BlueprintName == "" {
+		return "", ""
+	}
+	container := filepath.Base(strings.TrimPrefix(scheme.ReferencedContainer, "container:"))
+	for _, s := range DetectTargetSettings(workspacePath) {
+		if s.Target != scheme.BlueprintName || s.Configuration != configuration {
+			continue
+		}
+		if scheme.ReferencedContainer != "" && filepath.Base(s.ProjectPath) != container {
+			continue
+		}
+		if s.BundleID == "" || strings.Contains(s.BundleID, "$") {
+			return "", ""
+		}
+		return s.Target, s.BundleID
+	}
+	return "", ""
+}
@@ -0,0 +1,81 @@
+package wizard
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseShowBuildSettingsBundleID(t *testing.T) {
+	raw := []byte(`[
+		{"target":"Widget","buildSettings":{"PRODUCT_BUNDLE_IDENTIFIER":"com.example.app.widget","PRODUCT_TYPE":"com.apple.product-type.app-extension"}},
+		{"target":"App","buildSettings":{"PRODUCT_BUNDLE_IDENTIFIER":"com.example.app","PRODUCT_TYPE":"com.apple.product-type.application"}}
+	]`)
+	if got := parseShowBuildSettingsBundleID(raw); got != "com.example.app" {
+		t.Errorf("expected application bundle ID, got %q", got)
+	}
+	if got := parseShowBuildSettingsBundleID([]byte("not json")); got != "" {
+		t.Errorf("expected empty result for invalid output, got %q", got)
+	}
+}
+
+// writeSchemeFixture writes the fixture project with a shared scheme that
+// archives target.
+func writeSchemeFixture(t *testing.T, root, target string) string {
+	t.Helper()
+	writeFixtureProject(t, root)
+	project := filepath.Join(root, "App.xcodeproj")
+	scheme := strings.ReplaceAll(testScheme, `BuildableName = "App.app" BlueprintName = "App"`,
+		`BuildableName = "`+target+`.app" BlueprintName = "`+target+`"`)
+	path := filepath.Join(project, "xcshareddata", "xcschemes", "App.xcscheme")
+	mkdirs(t, filepath.Dir(path))
+	if err := os.WriteFile(path, []byte(scheme), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return project
+}
+
+func TestBundleIDMismatchUsesSchemeTarget(t *testing.T) {
+	project := writeSchemeFixture(t, t.TempDir(), "App")
+	inputs := Inputs{Workspace: project, Scheme: "App", BundleID: "com.example.app"}
+	if got := bundleIDMismatch(inputs); got != "" {
+		t.Errorf("expected no mismatch, got %q", got)
+	}
+
+	// The debug ID exists in the project, but not in the Release configuration.
+	inputs.BundleID = "com.example.app.debug"
+	if got := bundleIDMismatch(inputs); !strings.Contains(got, "PRODUCT_BUNDLE_IDENTIFIER in Release is com.example.app,") {
+		t.Errorf("expected the App target's Release bundle ID in mismatch, got %q", got)
+	}
+}
+
+func TestBundleIDMismatchWithTwoAppTargets(t *testing.T) {
+	root := t.TempDir()
+	project := writeSchemeFixture(t, root, "Widget")
+	// Turn the extension into a second app, so that both targets build apps.
+	path := filepath.Join(project, "project.pbxproj")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content = []byte(strings.ReplaceAll(string(content), `"com.apple.product-type.app-extension"`, `"com.apple.product-type.application"`))
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// com.example.app belongs to the other app target, not the one the scheme archives.
+	inputs := Inputs{Workspace: project, Scheme: "App", BundleID: "com.example.app"}
+	if got := bundleIDMismatch(inputs); !strings.Contains(got, "archives target Widget") || !strings.Contains(got, "is com.example.app.Widget") {
+		t.Errorf("expected a mismatch naming the Widget target, got %q", got)
+	}
+}
+
+func TestBundleIDMismatchUnresolvedScheme(t *testing.T) {
+	root := t.TempDir()
+	writeFixtureProject(t, root)
+	inputs := Inputs{Workspace: filepath.Join(root, "App.xcodeproj"), Scheme: "App", BundleID: "com.example.other"}
+	if got := bundleIDMismatch(inputs); got != "" {
+		t.Errorf("expected no verdict without a scheme file, got %q", got)
+	}
+}
@@ -0,0 +1,328 @@
+package wizard
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/term"
+)
+
+// CheckStatus is the outcome of a single audit check.
+type CheckStatus int
+
+const (
+	CheckPass CheckStatus = iota
+	CheckWarn
+	CheckFail
+)
+
+func (s CheckStatus) String() string {
+	switch s {
+	case CheckPass:
+		return "pass"
+	case CheckWarn:
+		return "warn"
+	default:
+		return "fail"
+	}
+}
+
+// CheckResult is one row of the `check` and `doctor` reports. Hint is an
+// optional remediation printed under warnings and failures.
+type CheckResult struct {
+	Name   string
+	Status CheckStatus
+	Detail string
+	Hint   string
+}
+
+var (
+	requiredSecretNames   = []string{"ASC_KEY_ID", "ASC_ISSUER_ID", "ASC_PRIVATE_KEY_B64"}
+	requiredVariableNames = []string{"ASC_APP_ID", "ASC_TEAM_ID", "BUNDLE_ID"}
+)
+
+// Check audits the current repository setup without changing anything. Values
+// in opts take precedence over the project config, which takes precedence over
+// those read from the existing workflow file.
+// Returns an error when at least one check fails.
+func Check(out io.Writer, opts Options) error {
+	theme := term.NewTheme()
+
+	fmt.Fprintln(out, theme.Title("ReleaseKit-iOS Check"))
+	fmt.Fprintln(out, theme.Muted("Read-only audit of the release setup for this repository."))
+	fmt.Fprintln(out)
+
+	var results []CheckResult
+
+	// Project config, then the existing workflow, fill values not in opts.
+	var saved Config
+	switch cfg, err := LoadConfig(DefaultConfigPath); {
+	case err == nil:
+		saved = cfg
+		results = append(results, CheckResult{Name: "Project config", Status: CheckPass, Detail: DefaultConfigPath})
+	case fileExists(DefaultConfigPath):
+		results = append(results, CheckResult{Name: "Project config", Status: CheckFail, Detail: err.Error()})
+	default:
+		results = append(results, CheckResult{Name: "Project config", Status: CheckWarn, Detail: DefaultConfigPath + " not found", Hint: "Run: releasekit-ios wizard"})
+	}
+
+	inputs := saved.Inputs()
+	container, err := opts.container()
+	if err != nil {
+		return err
+	}
+	if container != "" {
+		inputs.setContainer(container)
+	}
+	if v := strings.TrimSpace(opts.Scheme); v != "" {
+		inputs.Scheme = v
+	}
+	workflowPath := inputs.WorkflowPath
+	existing, readErr := os.ReadFile(workflowPath)
+	if readErr == nil {
+		if inputs.Workspace == "" {
+			if v := workflowInputValue(string(existing), "workspace"); v != "" {
+				inputs.setContainer(v)
+			} else if v := workflowInputValue(string(existing), "project"); v != "" {
+				inputs.setContainer(v)
+			}
+		}
+		if inputs.Scheme == "" {
+			inputs.Scheme = workflowInputValue(string(existing), "scheme")
+		}
+	}
+
+	candidates := detectAllWorkspaceCandidates(".")
+	if inputs.Workspace == "" && len(candidates) == 1 {
+		inputs.setContainer(candidates[0])
+	}
+	results = append(results, checkWorkspace(inputs.Workspace, candidates))
+
+	var schemes []string
+	if inputs.Workspace != "" {
+		schemes, _ = DetectSchemes(inputs.Workspace)
+		if inputs.Scheme == "" && len(schemes) == 1 {
+			inputs.Scheme = schemes[0]
+		}
+	}
+	results = append(results, checkScheme(inputs.Scheme, schemes))
+	if inputs.Workspace != "" && inputs.Scheme != "" {
+		results = append(results, checkSchemeShared(inputs.Workspace, inputs.Scheme))
+	}
+
+	if readErr != nil {
+		results = append(results, CheckResult{Name: "Workflow file", Status: CheckFail, Detail: workflowPath + " not found (run: releasekit-ios wizard)"})
+	} else {
+		expected, genErr := GenerateWorkflow(inputs.WorkflowOptions())
+		if genErr != nil {
+			return fmt.Errorf("failed to generate workflow: %w", genErr)
+		}
+		results = append(results, checkWorkflowContent(workflowPath, string(existing), expected))
+		results = append(results, checkWorkflowInputs(string(existing)))
+	}
+
+	var detectedTeamID string
+	if inputs.Workspace != "" {
+		detectedTeamID, _ = DetectTeamID(inputs.Workspace)
+	}
+	configuredTeamID := strings.TrimSpace(opts.TeamID)
+	if configuredTeamID == "" {
+		configuredTeamID = inputs.TeamID
+	}
+
+	// GitHub secrets and variables.
+	repoSlug := strings.TrimSpace(opts.GitHubRepo)
+	if repoSlug == "" {
+		repoSlug = inputs.GitHubRepo
+	}
+	canReadGitHub := canSetGitHubSettings(commandExists("gh") && ghIsAuthenticated())
+	if repoSlug == "" && canReadGitHub {
+		if owner, repo, err := DetectGitRepo(); err == nil {
+			repoSlug = owner + "/" + repo
+		}
+	}
+
+	switch {
+	case !canReadGitHub:
+		results = append(results, CheckResult{Name: "GitHub", Status: CheckWarn,
+			Detail: "no GitHub token (GITHUB_TOKEN or GH_TOKEN) and gh not authenticated; secrets and variables not checked"})
+	case repoSlug == "":
+		results = append(results, CheckResult{Name: "GitHub", Status: CheckWarn, Detail: "repository not detected (pass --repo); secrets and variables not checked"})
+	default:
+		names, err := ListGitHubSecretNames(repoSlug)
+		if err != nil {
+			results = append(results, CheckResult{Name: "GitHub secrets", Status: CheckFail, Detail: err.Error()})
+		} else {
+			results = append(results, checkSecretNames(names)...)
+		}
+
+		vars, err := ListGitHubVariables(repoSlug)
+		if err != nil {
+			results = append(results, CheckResult{Name: "GitHub variables", Status: CheckFail, Detail: err.Error()})
+		} else {
+			results = append(results, checkVariables(vars)...)
+			if configuredTeamID == "" {
+				configuredTeamID = vars["ASC_TEAM_ID"]
+			}
+		}
+	}
+
+	results = append(results, checkTeamID(detectedTeamID, configuredTeamID))
+
+	failures := printCheckResults(out, theme, results)
+	if failures > 0 {
+		return fmt.Errorf("check failed: %d problem(s) found", failures)
+	}
+	return nil
+}
+
+// workflowInputValue returns the key input of the workflow's archive step,
+// read from its with: mapping. Returns "" when the workflow cannot be parsed
+// or the step does not set key.
+func workflowInputValue(content, key string) string {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil || len(root.Content) == 0 {
+		return ""
+	}
+	for _, step := range jobSteps(root.Content[0]) {
+		if actionName(step) != "archive" {
+			continue
+		}
+		if value := mappingValue(mappingValue(step, "with"), key); value != nil && value.Kind == yaml.ScalarNode {
+			return strings.TrimSpace(value.Value)
+		}
+		return ""
+	}
+	return ""
+}
+
+func checkWorkspace(workspace string, candidates []string) CheckResult {
+	name := "Xcode workspace"
+	if ProjectKindForPath(workspace) == KindProject {
+		name = "Xcode project"
+	}
+	switch {
+	case workspace == "" && len(candidates) == 0:
+		return CheckResult{Name: name, Status: CheckFail, Detail: "no .xcworkspace or .xcodeproj found"}
+	case workspace == "":
+		return CheckResult{Name: name, Status: CheckWarn, Detail: "multiple workspaces or projects found (pass --workspace or --project): " + strings.Join(candidates, ", ")}
+	case !fileExists(workspace):
+		return CheckResult{Name: name, Status: CheckFail, Detail: workspace + " does not exist"}
+	case !slices.Contains(candidates, workspace):
+		return CheckResult{Name: name, Status: CheckWarn, Detail: workspace + " exists but was not found by detection"}
+	default:
+		return CheckResult{Name: name, Status: CheckPass, Detail: workspace}
+	}
+}
+
+func checkScheme(scheme string, schemes []string) CheckResult {
+	name := "Xcode scheme"
+	switch {
+	case scheme == "":
+		return CheckResult{Name: name, Status: CheckFail, Detail: "no scheme configured (pass --scheme)"}
+	case len(schemes) == 0:
+		return CheckResult{Name: name, Status: CheckWarn, Detail: scheme + " (no shared schemes found)",
+			Hint: "Share the scheme in Xcode (Product > Scheme > Manage Schemes > Shared) and commit its .xcscheme file"}
+	case !slices.Contains(schemes, scheme):
+		return CheckResult{Name: name, Status: CheckFail, Detail: scheme + " not found; available: " + strings.Join(schemes, ", ")}
+	default:
+		return CheckResult{Name: name, Status: CheckPass, Detail: scheme}
+	}
+}
+
+func checkWorkflowContent(path, existing, expected string) CheckResult {
+	name := "Workflow file"
+	if existing == expected {
+		return CheckResult{Name: name, Status: CheckPass, Detail: path + " matches the generated workflow"}
+	}
+	return CheckResult{Name: name, Status: CheckWarn, Detail: path + " differs from the generated workflow"}
+}
+
+// checkWorkflowInputs validates the committed workflow's action steps against
+// the inputs the archive and upload actions declare.
+func checkWorkflowInputs(content string) CheckResult {
+	name := "Workflow inputs"
+	issues, err := ValidateWorkflow(content)
+	if err != nil {
+		return CheckResult{Name: name, Status: CheckFail, Detail: err.Error()}
+	}
+	if len(issues) == 0 {
+		return CheckResult{Name: name, Status: CheckPass, Detail: "every action step matches its declared inputs"}
+	}
+	details := make([]string, len(issues))
+	for i, issue := range issues {
+		details[i] = issue.String()
+	}
+	return CheckResult{Name: name, Status: CheckFail, Detail: strings.Join(details, "; "), Hint: "Regenerate the workflow with: releasekit-ios wizard"}
+}
+
+func checkSecretNames(names []string) []CheckResult {
+	results := make([]CheckResult, 0, len(requiredSecretNames))
+	for _, secret := range requiredSecretNames {
+		if slices.Contains(names, secret) {
+			results = append(results, CheckResult{Name: "Secret " + secret, Status: CheckPass, Detail: "present"})
+		} else {
+			results = append(results, CheckResult{Name: "Secret " + secret, Status: CheckFail, Detail: "missing"})
+		}
+	}
+	return results
+}
+
+func checkVariables(vars map[string]string) []CheckResult {
+	results := make([]CheckResult, 0, len(requiredVariableNames))
+	for _, variable := range requiredVariableNames {
+		if value := vars[variable]; value != "" {
+			results = append(results, CheckResult{Name: "Variable " + variable, Status: CheckPass, Detail: value})
+		} else {
+			results = append(results, CheckResult{Name: "Variable " + variable, Status: CheckFail, Detail: "missing"})
+		}
+	}
+	return results
+}
+
+func checkTeamID(detected, configured string) CheckResult {
+	name := "Team ID"
+	switch {
+	case detected == "":
+		return CheckResult{Name: name, Status: CheckWarn, Detail: "no DEVELOPMENT_TEAM found in project.pbxproj"}
+	case configured == "":
+		return CheckResult{Name: name, Status: CheckPass, Detail: detected + " (detected; nothing to compare against)"}
+	case configured != detected:
+		return CheckResult{Name: name, Status: CheckFail, Detail: fmt.Sprintf("configured %s but project uses %s", configured, detected)}
+	default:
+		return CheckResult{Name: name, Status: CheckPass, Detail: detected}
+	}
+}
+
+// printCheckResults renders results as a table and returns the failure count.
+func printCheckResults(out io.Writer, theme term.Theme, results []CheckResult) int {
+	width := 0
+	for _, r := range results {
+		width = max(width, len(r.Name))
+	}
+
+	failures := 0
+	for _, r := range results {
+		var status string
+		switch r.Status {
+		case CheckPass:
+			status = theme.Success("✓ pass")
+		case CheckWarn:
+			status = theme.Muted("! warn")
+		default:
+			status = theme.Failure("✗ fail")
+			failures++
+		}
+		fmt.Fprintf(out, "  %s  %s  %s\n", theme.Label(fmt.Sprintf("%-*s", width, r.Name)), status, theme.Value(r.Detail))
+		if r.Hint != "" && r.Status != CheckPass {
+			fmt.Fprintf(out, "  %s  %s\n", fmt.Sprintf("%-*s", width, ""), theme.Muted("→ "+r.Hint))
+		}
+	}
+	fmt.Fprintln(out)
+	return failures
+}
@@ -0,0 +1,169 @@
+package wizard
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/term"
+)
+
+func TestWorkflowInputValue(t *testing.T) {
+	content, err := GenerateWorkflow(WorkflowOptions{Container: "ios/App.xcworkspace", Scheme: "App"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := workflowInputValue(content, "workspace"); got != "ios/App.xcworkspace" {
+		t.Errorf("expected workspace 'ios/App.xcworkspace', got %q", got)
+	}
+	if got := workflowInputValue(content, "scheme"); got != "App" {
+		t.Errorf("expected scheme 'App', got %q", got)
+	}
+	quoted := `jobs:
+  release:
+    env:
+      scheme: Other
+    steps:
+      - run: xcodebuild test
+        with:
+          scheme: Tests
+      - uses: vinceglb/releasekit-ios/actions/archive@v0
+        with:
+          scheme: "My App" # main scheme
+`
+	if got := workflowInputValue(quoted, "scheme"); got != "My App" {
+		t.Errorf("expected the archive step's scheme, got %q", got)
+	}
+	if got := workflowInputValue(content, "missing"); got != "" {
+		t.Errorf("expected empty value for missing key, got %q", got)
+	}
+}
+
+func TestCheckWorkspace(t *testing.T) {
+	tmpDir := t.TempDir()
+	workspace := filepath.Join(tmpDir, "App.xcworkspace")
+	if err := os.Mkdir(workspace, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		workspace  string
+		candidates []string
+		want       CheckStatus
+	}{
+		{"none found", "", nil, CheckFail},
+		{"ambiguous", "", []string{"A.xcworkspace", "B.xcworkspace"}, CheckWarn},
+		{"missing on disk", filepath.Join(tmpDir, "Gone.xcworkspace"), nil, CheckFail},
+		{"not detected", workspace, []string{"Other.xcworkspace"}, CheckWarn},
+		{"resolves", workspace, []string{workspace}, CheckPass},
+	}
+	for _, tc := range cases {
+		if got := checkWorkspace(tc.workspace, tc.candidates).Status; got != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestCheckScheme(t *testing.T) {
+	if got := checkScheme("App", []string{"App", "AppTests"}).Status; got != CheckPass {
+		t.Errorf("expected pass, got %s", got)
+	}
+	if got := checkScheme("Gone", []string{"App"}).Status; got != CheckFail {
+		t.Errorf("expected fail for unknown scheme, got %s", got)
+	}
+	if got := checkScheme("App", nil); got.Status != CheckWarn || !strings.Contains(got.Detail, "no shared schemes") || got.Hint == "" {
+		t.Errorf("expected warn with a hint when no scheme is shared, got %+v", got)
+	}
+	if got := checkScheme("", nil).Status; got != CheckFail {
+		t.Errorf("expected fail for blank scheme, got %s", got)
+	}
+}
+
+func TestCheckWorkflowContent(t *testing.T) {
+	if got := checkWorkflowContent("release.yml", "a", "a").Status; got != CheckPass {
+		t.Errorf("expected pass for identical content, got %s", got)
+	}
+	if got := checkWorkflowContent("release.yml", "a", "b").Status; got != CheckWarn {
+		t.Errorf("expected warn for modified content, got %s", got)
+	}
+}
+
+func TestCheckSecretNames(t *testing.T) {
+	results := checkSecretNames([]string{"ASC_KEY_ID", "ASC_PRIVATE_KEY_B64", "OTHER"})
+	statuses := map[string]CheckStatus{}
+	for _, r := range results {
+		statuses[r.Name] = r.Status
+	}
+	if statuses["Secret ASC_KEY_ID"] != CheckPass {
+		t.Errorf("expected ASC_KEY_ID to pass")
+	}
+	if statuses["Secret ASC_ISSUER_ID"] != CheckFail {
+		t.Errorf("expected ASC_ISSUER_ID to fail")
+	}
+}
+
+func TestCheckVariables(t *testing.T) {
+	results := checkVariables(map[string]string{"ASC_APP_ID": "123", "BUNDLE_ID": ""})
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].Status != CheckPass {
+		t.Errorf("expected ASC_APP_ID to pass, got %s", results[0].Status)
+	}
+	if results[1].Status != CheckFail || results[2].Status != CheckFail {
+		t.Errorf("expected ASC_TEAM_ID and empty BUNDLE_ID to fail: %+v", results)
+	}
+}
+
+func TestCheckTeamID(t *testing.T) {
+	if got := checkTeamID("ABCDE12345", "ABCDE12345").Status; got != CheckPass {
+		t.Errorf("expected pass for matching team, got %s", got)
+	}
+	if got := checkTeamID("ABCDE12345", "ZZZZZ99999").Status; got != CheckFail {
+		t.Errorf("expected fail for mismatched team, got %s", got)
+	}
+	if got := checkTeamID("", "ABCDE12345").Status; got != CheckWarn {
+		t.Errorf("expected warn when no team detected, got %s", got)
+	}
+}
+
+func TestPrintCheckResultsCountsFailures(t *testing.T) {
+	var out bytes.Buffer
+	failures := printCheckResults(&out, term.NewTheme(), []CheckResult{
+		{Name: "One", Status: CheckPass, Detail: "ok"},
+		{Name: "Two", Status: CheckWarn, Detail: "hmm"},
+		{Name: "Three", Status: CheckFail, Detail: "bad"},
+	})
+	if failures != 1 {
+		t.Errorf("expected 1 failure, got %d", failures)
+	}
+	if !bytes.Contains(out.Bytes(), []byte("Three")) {
+		t.Errorf("expected table to contain every row, got:\n%s", out.String())
+	}
+}
+
+func TestCheckWorkflowInputs(t *testing.T) {
+	content, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcworkspace", Scheme: "App"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := checkWorkflowInputs(content); got.Status != CheckPass {
+		t.Errorf("expected pass for generated workflow, got %s: %s", got.Status, got.Detail)
+	}
+
+	stale := strings.Replace(content, "bundle_id:", "bundle-id:", 1)
+	got := checkWorkflowInputs(stale)
+	if got.Status != CheckFail {
+		t.Fatalf("expected fail for hyphenated input, got %s", got.Status)
+	}
+	if !strings.Contains(got.Detail, `did you mean "bundle_id"?`) {
+		t.Errorf("expected a suggestion in detail, got %q", got.Detail)
+	}
+
+	if got := checkWorkflowInputs("jobs: [").Status; got != CheckFail {
+		t.Errorf("expected fail for invalid YAML, got %s", got)
+	}
+}
@@ -0,0 +1,371 @@
+package wizard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConfigPath is the conventional location of the project config file.
+const DefaultConfigPath = ".releasekit.yml"
+
+// ConfigVersion is the schema version written by this CLI.
+const ConfigVersion = 1
+
+// Config is the committed, non-secret project configuration. Secrets never
+// live in this file; they come from flags or RELEASEKIT_* env vars.
+type Config struct {
+	Version       int    `yaml:"version"`
+	Workspace     string `yaml:"workspace,omitempty"`
+	Project       string `yaml:"project,omitempty"` // standalone .xcodeproj, instead of workspace
+	Scheme        string `yaml:"scheme"`
+	BundleID      string `yaml:"bundle_id"`
+	TeamID        string `yaml:"team_id"`
+	AppID         string `yaml:"app_id"`
+	Configuration string `yaml:"configuration,omitempty"`
+	GitHubRepo    string `yaml:"repo,omitempty"`
+	WorkflowPath  string `yaml:"workflow_path,omitempty"`
+	RunnerLabel   string `yaml:"runner_label,omitempty"` // comma-separated, e.g. "self-hosted, macOS"
+
+	// WorkflowTemplate replaces the built-in workflow template; see WorkflowData.
+	WorkflowTemplate string `yaml:"workflow_template,omitempty"`
+
+	XcodeVersion        string `yaml:"xcode_version,omitempty"`
+	ActionRef           string `yaml:"action_ref,omitempty"`
+	XcodebuildExtraArgs string `yaml:"xcodebuild_extra_args,omitempty"`
+
+	// WorkflowLayout, ArtifactName and ArtifactRetentionDays shape the
+	// generated workflow; see GenerateWorkflow.
+	WorkflowLayout        string `yaml:"workflow_layout,omitempty"`
+	ArtifactName          string `yaml:"artifact_name,omitempty"`
+	ArtifactRetentionDays int    `yaml:"artifact_retention_days,omitempty"`
+
+	// ExportOptionsPath is where `export-options` writes the rendered plist;
+	// the workflow passes it to the archive action.
+	ExportOptionsPath string               `yaml:"export_options_path,omitempty"`
+	ExportOptions     *ExportOptionsConfig `yaml:"export_options,omitempty"`
+}
+
+// configField maps a config key to its Config field.
+type configField struct {
+	key      string
+	required bool
+	target   func(*Config) *string
+}
+
+var configFields = []configField{
+	{"workspace", false, func(c *Config) *string { return &c.Workspace }},
+	{"project", false, func(c *Config) *string { return &c.Project }},
+	{"scheme", true, func(c *Config) *string { return &c.Scheme }},
+	{"bundle_id", true, func(c *Config) *string { return &c.BundleID }},
+	{"team_id", true, func(c *Config) *string { return &c.TeamID }},
+	{"app_id", true, func(c *Config) *string { return &c.AppID }},
+	{"configuration", false, func(c *Config) *string { return &c.Configuration }},
+	{"repo", false, func(c *Config) *string { return &c.GitHubRepo }},
+	{"workflow_path", false, func(c *Config) *string { return &c.WorkflowPath }},
+	{"workflow_template", false, func(c *Config) *string { return &c.WorkflowTemplate }},
+	{"runner_label", false, func(c *Config) *string { return &c.RunnerLabel }},
+	{"xcode_version", false, func(c *Config) *string { return &c.XcodeVersion }},
+	{"action_ref", false, func(c *Config) *string { return &c.ActionRef }},
+	{"xcodebuild_extra_args", false, func(c *Config) *string { return &c.XcodebuildExtraArgs }},
+	{"workflow_layout", false, func(c *Config) *string { return &c.WorkflowLayout }},
+	{"artifact_name", false, func(c *Config) *string { return &c.ArtifactName }},
+	{"export_options_path", false, func(c *Config) *string { return &c.ExportOptionsPath }},
+}
+
+// secretConfigKeys are rejected with a dedicated message so that credentials
+// never end up committed next to the code.
+var secretConfigKeys = map[string]bool{
+	"asc_key_id":          true,
+	"asc_issuer_id":       true,
+	"asc_private_key":     true,
+	"asc_private_key_b64": true,
+	"p8_b64":              true,
+	"p8_path":             true,
+	"private_key":         true,
+}
+
+// ConfigError lists every schema problem found in a config file, each with
+// the line it was found on.
+type ConfigError struct {
+	Path   string
+	Issues []ConfigIssue
+}
+
+// ConfigIssue is a single schema problem. Line is 0 when the problem is not
+// tied to a specific line (e.g. a missing key).
+type ConfigIssue struct {
+	Line    int
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	lines := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		if issue.Line > 0 {
+			lines[i] = fmt.Sprintf("%s:%d: %s", e.Path, issue.Line, issue.Message)
+		} else {
+			lines[i] = fmt.Sprintf("%s: %s", e.Path, issue.Message)
+		}
+	}
+	if len(lines) == 1 {
+		return "invalid config " + lines[0]
+	}
+	return "invalid config:\n  " + strings.Join(lines, "\n  ")
+}
+
+// LoadConfig reads and validates the config file at path.
+func LoadConfig(path string) (Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("could not read config: %w", err)
+	}
+	return parseConfig(path, content)
+}
+
+// parseConfig validates content against the config schema. Unlike a plain
+// yaml.Unmarshal it reports every problem at once, with line numbers.
+func parseConfig(path string, content []byte) (Config, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return Config{}, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	cfgErr := &ConfigError{Path: path}
+	issue := func(line int, format string, args ...any) {
+		cfgErr.Issues = append(cfgErr.Issues, ConfigIssue{Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(doc.Content) == 0 {
+		issue(0, "file is empty")
+		return Config{}, cfgErr
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		issue(root.Line, "expected a mapping of keys to values")
+		return Config{}, cfgErr
+	}
+
+	var cfg Config
+	seen := make(map[string]int)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+
+		if first, dup := seen[key.Value]; dup {
+			issue(key.Line, "duplicate key %q (first defined on line %d)", key.Value, first)
+			continue
+		}
+		seen[key.Value] = key.Line
+
+		if key.Value == "version" {
+			version, convErr := strconv.Atoi(value.Value)
+			if value.Kind != yaml.ScalarNode || convErr != nil {
+				issue(value.Line, "version must be an integer")
+				continue
+			}
+			if version != ConfigVersion {
+				issue(value.Line, "unsupported version %d (this CLI supports version %d)", version, ConfigVersion)
+			}
+			cfg.Version = version
+			continue
+		}
+
+		if key.Value == "artifact_retention_days" {
+			days, convErr := strconv.Atoi(value.Value)
+			if value.Kind != yaml.ScalarNode || convErr != nil || days < 1 || days > 90 {
+				issue(value.Line, "artifact_retention_days must be a number of days between 1 and 90")
+				continue
+			}
+			cfg.ArtifactRetentionDays = days
+			continue
+		}
+
+		if key.Value == "export_options" {
+			cfg.ExportOptions = parseExportOptionsNode(value, issue)
+			continue
+		}
+
+		if secretConfigKeys[key.Value] {
+			issue(key.Line, "%q is a secret and must not be stored in the config; use GitHub secrets or %s env vars", key.Value, "RELEASEKIT_*")
+			continue
+		}
+
+		field, ok := lookupConfigField(key.Value)
+		if !ok {
+			issue(key.Line, "unknown key %q", key.Value)
+			continue
+		}
+		if value.Kind != yaml.ScalarNode || value.Tag == "!!null" {
+			issue(value.Line, "%s must be a string", key.Value)
+			continue
+		}
+		*field.target(&cfg) = strings.TrimSpace(value.Value)
+	}
+
+	if _, ok := seen["version"]; !ok {
+		issue(0, "missing required key \"version\" (current version is %d)", ConfigVersion)
+	}
+	for _, field := range configFields {
+		if !field.required {
+			continue
+		}
+		if line, ok := seen[field.key]; !ok {
+			issue(0, "missing required key %q", field.key)
+		} else if *field.target(&cfg) == "" {
+			issue(line, "%s must not be empty", field.key)
+		}
+	}
+	// Exactly one of workspace and project, like the archive action inputs.
+	workspaceLine, hasWorkspace := seen["workspace"]
+	projectLine, hasProject := seen["project"]
+	switch {
+	case hasWorkspace && hasProject:
+		issue(projectLine, "set either workspace or project, not both (workspace is on line %d)", workspaceLine)
+	case !hasWorkspace && !hasProject:
+		issue(0, "missing required key \"workspace\" (or \"project\" for a standalone .xcodeproj)")
+	}
+
+	if line, ok := seen["workflow_layout"]; ok && cfg.WorkflowLayout != "" &&
+		cfg.WorkflowLayout != LayoutTwoJobs && cfg.WorkflowLayout != LayoutSingleJob {
+		issue(line, "workflow_layout must be %q or %q", LayoutTwoJobs, LayoutSingleJob)
+	}
+	for _, check := range []struct {
+		key      string
+		value    string
+		validate func(string) error
+	}{
+		{"runner_label", cfg.RunnerLabel, func(v string) error { return validateRunnerLabels(ParseRunnerLabels(v)) }},
+		{"xcode_version", cfg.XcodeVersion, validateXcodeVersion},
+		{"action_ref", cfg.ActionRef, validateActionRef},
+	} {
+		if line, ok := seen[check.key]; ok && check.value != "" {
+			if err := check.validate(check.value); err != nil {
+				issue(line, "%s: %v", check.key, err)
+			}
+		}
+	}
+	if line, ok := seen["export_options"]; ok && cfg.ExportOptions != nil && len(cfgErr.Issues) == 0 {
+		if _, err := BuildExportOptions(cfg); err != nil {
+			issue(line, "export_options: %v", err)
+		}
+	}
+
+	if len(cfgErr.Issues) > 0 {
+		return Config{}, cfgErr
+	}
+	return cfg, nil
+}
+
+func lookupConfigField(key string) (configField, bool) {
+	for _, field := range configFields {
+		if field.key == key {
+			return field, true
+		}
+	}
+	return configField{}, false
+}
+
+// SaveConfig writes cfg to path with a short header. Only the non-secret
+// fields of Config can be written.
+func SaveConfig(path string, cfg Config) error {
+	cfg.Version = ConfigVersion
+	body, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	header := "# ReleaseKit-iOS project config. Secrets are never stored in this file.\n"
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, append([]byte(header), body...), 0644)
+}
+
+// configUpToDate reports whether the config file at path is valid and already
+// holds the values of cfg, so that rewriting it would change nothing.
+func configUpToDate(path string, cfg Config) bool {
+	if !fileExists(path) {
+		return false
+	}
+	current, err := LoadConfig(path)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(ConfigFromInputs(current.Inputs()), cfg)
+}
+
+// ConfigFromInputs extracts the non-secret fields of inputs.
+func ConfigFromInputs(inputs Inputs) Config {
+	cfg := Config{
+		Version:       ConfigVersion,
+		Scheme:        inputs.Scheme,
+		BundleID:      inputs.BundleID,
+		TeamID:        inputs.TeamID,
+		AppID:         inputs.AppID,
+		Configuration: inputs.Configuration,
+		GitHubRepo:    inputs.GitHubRepo,
+		RunnerLabel:   inputs.RunnerLabel,
+
+		WorkflowTemplate:      inputs.WorkflowTemplate,
+		XcodeVersion:          inputs.XcodeVersion,
+		ActionRef:             inputs.ActionRef,
+		XcodebuildExtraArgs:   inputs.XcodebuildExtraArgs,
+		WorkflowLayout:        inputs.WorkflowLayout,
+		ArtifactName:          inputs.ArtifactName,
+		ArtifactRetentionDays: inputs.ArtifactRetentionDays,
+		ExportOptionsPath:     inputs.ExportOptionsPath,
+		ExportOptions:         inputs.ExportOptions,
+	}
+	if inputs.Kind() == KindProject {
+		cfg.Project = inputs.Workspace
+	} else {
+		cfg.Workspace = inputs.Workspace
+	}
+	if inputs.WorkflowPath != DefaultWorkflowPath() {
+		cfg.WorkflowPath = inputs.WorkflowPath
+	}
+	return cfg
+}
+
+// Inputs converts the config into wizard inputs without any secrets.
+func (c Config) Inputs() Inputs {
+	workflowPath := strings.TrimSpace(c.WorkflowPath)
+	if workflowPath == "" {
+		workflowPath = DefaultWorkflowPath()
+	}
+	inputs := Inputs{
+		Workspace:     strings.TrimSpace(c.Workspace),
+		Scheme:        strings.TrimSpace(c.Scheme),
+		BundleID:      strings.TrimSpace(c.BundleID),
+		TeamID:        strings.TrimSpace(c.TeamID),
+		AppID:         strings.TrimSpace(c.AppID),
+		Configuration: strings.TrimSpace(c.Configuration),
+		GitHubRepo:    strings.TrimSpace(c.GitHubRepo),
+		WorkflowPath:  workflowPath,
+		RunnerLabel:   strings.TrimSpace(c.RunnerLabel),
+
+		WorkflowTemplate:      strings.TrimSpace(c.WorkflowTemplate),
+		XcodeVersion:          strings.TrimSpace(c.XcodeVersion),
+		ActionRef:             strings.TrimSpace(c.ActionRef),
+		XcodebuildExtraArgs:   strings.TrimSpace(c.XcodebuildExtraArgs),
+		WorkflowLayout:        strings.TrimSpace(c.WorkflowLayout),
+		ArtifactName:          strings.TrimSpace(c.ArtifactName),
+		ArtifactRetentionDays: c.ArtifactRetentionDays,
+		ExportOptionsPath:     strings.TrimSpace(c.ExportOptionsPath),
+		ExportOptions:         c.ExportOptions,
+	}
+	switch project := strings.TrimSpace(c.Project); {
+	case project != "":
+		inputs.Workspace = project
+		inputs.ProjectKind = KindProject
+	case inputs.Workspace != "":
+		inputs.ProjectKind = KindWorkspace
+	}
+	return inputs
+}
@@ -0,0 +1,299 @@
+package wizard
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/term"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultConfigPath)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := writeConfig(t, `version: 1
+workspace: ios/App.xcworkspace
+scheme: App
+bundle_id: com.example.app
+team_id: ABCDE12345
+app_id: 123456789
+configuration: Staging
+repo: acme/app
+runner_label: macos-15
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	inputs := cfg.Inputs()
+	if inputs.Workspace != "ios/App.xcworkspace" || inputs.Scheme != "App" {
+		t.Errorf("unexpected workspace/scheme: %+v", inputs)
+	}
+	if inputs.AppID != "123456789" || inputs.GitHubRepo != "acme/app" {
+		t.Errorf("unexpected app ID/repo: %+v", inputs)
+	}
+	if inputs.Configuration != "Staging" || inputs.RunnerLabel != "macos-15" {
+		t.Errorf("unexpected configuration/runner: %+v", inputs)
+	}
+	if inputs.WorkflowPath != DefaultWorkflowPath() {
+		t.Errorf("expected default workflow path, got %q", inputs.WorkflowPath)
+	}
+}
+
+func TestLoadConfigMissingKeys(t *testing.T) {
+	path := writeConfig(t, "version: 1\nworkspace: App.xcworkspace\n")
+
+	_, err := LoadConfig(path)
+	if err == nil {
+		t.Fatal("expected error for missing keys")
+	}
+	for _, key := range []string{"scheme", "bundle_id", "team_id", "app_id"} {
+		if !strings.Contains(err.Error(), `missing required key "`+key+`"`) {
+			t.Errorf("expected %s to be listed, got: %v", key, err)
+		}
+	}
+}
+
+func TestLoadConfigReportsLineNumbers(t *testing.T) {
+	path := writeConfig(t, `version: 1
+workspace: App.xcworkspace
+scheme: App
+bundle_id: com.example.app
+team_id: ABCDE12345
+app_id: "123"
+runer_label: macos-15
+scheme: Other
+configuration:
+  - Release
+`)
+
+	_, err := LoadConfig(path)
+	var cfgErr *ConfigError
+	if !errors.As(err, &cfgErr) {
+		t.Fatalf("expected *ConfigError, got %v", err)
+	}
+
+	want := map[int]string{
+		7:  `unknown key "runer_label"`,
+		8:  `duplicate key "scheme" (first defined on line 3)`,
+		10: "configuration must be a string",
+	}
+	if len(cfgErr.Issues) != len(want) {
+		t.Fatalf("expected %d issues, got %v", len(want), cfgErr.Issues)
+	}
+	for _, issue := range cfgErr.Issues {
+		if want[issue.Line] != issue.Message {
+			t.Errorf("line %d: unexpected issue %q", issue.Line, issue.Message)
+		}
+	}
+	if !strings.Contains(err.Error(), DefaultConfigPath+":7: unknown key") {
+		t.Errorf("expected path:line prefix in error, got: %v", err)
+	}
+}
+
+func TestLoadConfigRejectsSecrets(t *testing.T) {
+	path := writeConfig(t, "version: 1\nasc_private_key_b64: abc\n")
+
+	_, err := LoadConfig(path)
+	if err == nil || !strings.Contains(err.Error(), ":2: \"asc_private_key_b64\" is a secret") {
+		t.Fatalf("expected secret key to be rejected with its line, got: %v", err)
+	}
+}
+
+func TestLoadConfigVersion(t *testing.T) {
+	base := "workspace: A.xcworkspace\nscheme: A\nbundle_id: a.b\nteam_id: ABCDE12345\napp_id: '1'\n"
+
+	if _, err := LoadConfig(writeConfig(t, base)); err == nil || !strings.Contains(err.Error(), `"version"`) {
+		t.Errorf("expected missing version error, got: %v", err)
+	}
+	if _, err := LoadConfig(writeConfig(t, "version: 2\n"+base)); err == nil || !strings.Contains(err.Error(), ":1: unsupported version 2") {
+		t.Errorf("expected unsupported version error, got: %v", err)
+	}
+}
+
+func TestLoadConfigInvalidYAML(t *testing.T) {
+	if _, err := LoadConfig(writeConfig(t, "version: [1\n")); err == nil {
+		t.Fatal("expected YAML syntax error")
+	}
+	if _, err := LoadConfig(writeConfig(t, "- a\n- b\n")); err == nil {
+		t.Fatal("expected error for non-mapping document")
+	}
+}
+
+func TestLoadConfigNotFound(t *testing.T) {
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yml")); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestSaveConfigRoundTripWithoutSecrets(t *testing.T) {
+	inputs := Inputs{
+		Workspace:        "App.xcworkspace",
+		Scheme:           "App",
+		BundleID:         "com.example.app",
+		TeamID:           "ABCDE12345",
+		AppID:            "123456789",
+		ASCKeyID:         "KEYID12345",
+		ASCIssuerID:      "00000000-0000-0000-0000-000000000000",
+		ASCPrivateKeyB64: "c2VjcmV0LWtleQ==",
+		WorkflowPath:     DefaultWorkflowPath(),
+		RunnerLabel:      "macos-15",
+	}
+	path := filepath.Join(t.TempDir(), DefaultConfigPath)
+	if err := SaveConfig(path, ConfigFromInputs(inputs)); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{inputs.ASCKeyID, inputs.ASCIssuerID, inputs.ASCPrivateKeyB64} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("secret %q written to config:\n%s", secret, content)
+		}
+	}
+	if strings.Contains(string(content), "workflow_path") {
+		t.Errorf("default workflow path should be omitted:\n%s", content)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig after SaveConfig: %v", err)
+	}
+	if cfg.Version != ConfigVersion || cfg.RunnerLabel != "macos-15" || cfg.AppID != "123456789" {
+		t.Errorf("unexpected round trip: %+v", cfg)
+	}
+}
+
+func TestLoadConfigStandaloneProject(t *testing.T) {
+	path := writeConfig(t, `version: 1
+project: App.xcodeproj
+scheme: App
+bundle_id: com.example.app
+team_id: ABCDE12345
+app_id: "123456789"
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	inputs := cfg.Inputs()
+	if inputs.Workspace != "App.xcodeproj" || inputs.Kind() != KindProject {
+		t.Errorf("expected standalone project, got %+v", inputs)
+	}
+	if got := ConfigFromInputs(inputs); got.Project != "App.xcodeproj" || got.Workspace != "" {
+		t.Errorf("expected project key on save, got %+v", got)
+	}
+}
+
+func TestLoadConfigWorkspaceOrProject(t *testing.T) {
+	base := "version: 1\nscheme: App\nbundle_id: com.example.app\nteam_id: ABCDE12345\napp_id: \"1\"\n"
+
+	_, err := LoadConfig(writeConfig(t, base))
+	if err == nil || !strings.Contains(err.Error(), `missing required key "workspace"`) {
+		t.Errorf("expected missing workspace, got: %v", err)
+	}
+
+	_, err = LoadConfig(writeConfig(t, base+"workspace: App.xcworkspace\nproject: App.xcodeproj\n"))
+	if err == nil || !strings.Contains(err.Error(), ":7: set either workspace or project, not both (workspace is on line 6)") {
+		t.Errorf("expected workspace/project conflict, got: %v", err)
+	}
+}
+
+func TestLoadConfigWorkflowLayout(t *testing.T) {
+	base := "version: 1\nworkspace: App.xcworkspace\nscheme: App\nbundle_id: com.example.app\nteam_id: ABCDE12345\napp_id: \"1\"\n"
+
+	cfg, err := LoadConfig(writeConfig(t, base+"workflow_layout: single-job\nartifact_name: app-ipa\nartifact_retention_days: 14\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inputs := cfg.Inputs(); inputs.WorkflowLayout != LayoutSingleJob || inputs.ArtifactName != "app-ipa" || inputs.ArtifactRetentionDays != 14 {
+		t.Errorf("unexpected inputs: %+v", inputs)
+	}
+
+	_, err = LoadConfig(writeConfig(t, base+"workflow_layout: one-job\nartifact_retention_days: 365\n"))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{":7: workflow_layout must be", ":8: artifact_retention_days must be a number of days between 1 and 90"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in: %v", want, err)
+		}
+	}
+}
+
+func TestLoadConfigWorkflowOptions(t *testing.T) {
+	base := "version: 1\nworkspace: App.xcworkspace\nscheme: App\nbundle_id: com.example.app\nteam_id: ABCDE12345\napp_id: \"1\"\n"
+
+	cfg, err := LoadConfig(writeConfig(t, base+"workflow_template: ci/release.yml.tmpl\nrunner_label: self-hosted, macOS\nxcode_version: \"16.2\"\naction_ref: main\nxcodebuild_extra_args: -skipMacroValidation\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts := cfg.Inputs().WorkflowOptions()
+	if strings.Join(opts.RunnerLabels, "|") != "self-hosted|macOS" || opts.XcodeVersion != "16.2" ||
+		opts.ActionRef != "main" || opts.XcodebuildExtraArgs != "-skipMacroValidation" || opts.TemplatePath != "ci/release.yml.tmpl" {
+		t.Errorf("unexpected workflow options: %+v", opts)
+	}
+	if got := ConfigFromInputs(cfg.Inputs()); got.XcodeVersion != "16.2" || got.ActionRef != "main" {
+		t.Errorf("expected workflow options to round-trip, got %+v", got)
+	}
+
+	_, err = LoadConfig(writeConfig(t, base+"runner_label: macos 15\nxcode_version: sixteen\naction_ref: ../main\n"))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{":7: runner_label:", ":8: xcode_version:", ":9: action_ref:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in: %v", want, err)
+		}
+	}
+}
+
+func TestSaveProjectConfigKeepsUnchangedFile(t *testing.T) {
+	content := `# Hand-written notes survive when nothing changes.
+version: 1
+workspace: App.xcworkspace
+scheme: App # main scheme
+bundle_id: com.example.app
+team_id: ABCDE12345
+app_id: "123456789"
+`
+	path := writeConfig(t, content)
+	t.Chdir(filepath.Dir(path))
+
+	cfg, err := LoadConfig(DefaultConfigPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	inputs := cfg.Inputs()
+	var out strings.Builder
+	if err := saveProjectConfig(&out, term.NewTheme(), &inputs, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := os.ReadFile(DefaultConfigPath); string(got) != content {
+		t.Errorf("expected the config to be left as written, got:\n%s", got)
+	}
+	if !strings.Contains(out.String(), "up to date") || inputs.ConfigPath != DefaultConfigPath {
+		t.Errorf("unexpected output %q, config path %q", out.String(), inputs.ConfigPath)
+	}
+
+	inputs.Scheme = "App Staging"
+	if err := saveProjectConfig(&out, term.NewTheme(), &inputs, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved, err := LoadConfig(DefaultConfigPath); err != nil || saved.Scheme != "App Staging" {
+		t.Errorf("expected the changed scheme to be written, got %+v %v", saved, err)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/pbxproj"
 )
 
 func detectWorkspaceCandidate(root string) (string, bool) {
@@ -45,9 +47,11 @@ func detectWorkspaceCandidate(root string) (string, bool) {
 	return matches[0], true
 }
 
-// detectAllWorkspaceCandidates returns all .xcworkspace paths found under root.
+// detectAllWorkspaceCandidates returns all .xcworkspace paths found under root,
+// plus the standalone .xcodeproj bundles that none of those workspaces
+// reference. The project.xcworkspace embedded in every .xcodeproj is skipped.
 func detectAllWorkspaceCandidates(root string) []string {
-	var matches []string
+	var workspaces, projects []string
 
 	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -60,90 +64,227 @@ func detectAllWorkspaceCandidates(root string) []string {
 			case ".git", "Pods", "Carthage", ".build", "DerivedData", "node_modules", ".swiftpm":
 				return fs.SkipDir
 			}
-			if strings.HasSuffix(name, ".xcworkspace") {
-				rel, relErr := filepath.Rel(root, path)
-				if relErr == nil {
-					matches = append(matches, rel)
-				}
+			switch {
+			case strings.HasSuffix(name, ".xcworkspace"):
+				workspaces = append(workspaces, path)
+				return fs.SkipDir
+			case strings.HasSuffix(name, ".xcodeproj"):
+				projects = append(projects, path)
 				return fs.SkipDir
 			}
 		}
 		return nil
 	})
 
+	referenced := make(map[string]bool)
+	for _, ws := range workspaces {
+		refs, _ := WorkspaceProjects(ws)
+		for _, ref := range refs {
+			referenced[filepath.Clean(ref)] = true
+		}
+	}
+
+	var matches []string
+	for _, path := range workspaces {
+		if rel, relErr := filepath.Rel(root, path); relErr == nil {
+			matches = append(matches, rel)
+		}
+	}
+	for _, path := range projects {
+		if referenced[filepath.Clean(path)] {
+			continue
+		}
+		if rel, relErr := filepath.Rel(root, path); relErr == nil {
+			matches = append(matches, rel)
+		}
+	}
+
 	sort.Strings(matches)
 	return matches
 }
 
-// DetectSchemes runs xcodebuild -list on a workspace to discover available schemes.
-// Returns nil, nil on any failure (graceful fallback).
+// DetectSchemes runs xcodebuild -list on a workspace or standalone project to
+// discover available schemes. When xcodebuild is unavailable or times out it
+// falls back to the .xcscheme files on disk (see FindSchemeFiles).
+// Returns nil, nil when neither finds a scheme (graceful fallback).
 func DetectSchemes(workspacePath string) ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "xcodebuild", "-list", "-workspace", workspacePath, "-json")
-	out, err := cmd.Output()
-	if err != nil {
-		return nil, nil //nolint:nilerr // non-blocking; caller falls back to manual input
+	flag := ProjectKindForPath(workspacePath).xcodebuildFlag()
+	cmd := exec.CommandContext(ctx, "xcodebuild", "-list", flag, workspacePath, "-json")
+	if out, err := cmd.Output(); err == nil {
+		if schemes := parseListSchemes(out); len(schemes) > 0 {
+			return schemes, nil
+		}
 	}
 
+	var names []string
+	for _, scheme := range FindSchemeFiles(workspacePath) {
+		names = append(names, scheme.Name)
+	}
+	return names, nil
+}
+
+// parseListSchemes reads the schemes from `xcodebuild -list -json` output,
+// which nests them under "workspace" or "project" depending on the flag.
+func parseListSchemes(raw []byte) []string {
 	var result struct {
 		Workspace struct {
 			Schemes []string `json:"schemes"`
 		} `json:"workspace"`
+		Project struct {
+			Schemes []string `json:"schemes"`
+		} `json:"project"`
 	}
-	if err := json.Unmarshal(out, &result); err != nil {
-		return nil, nil //nolint:nilerr
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil
 	}
-	return result.Workspace.Schemes, nil
+	if len(result.Workspace.Schemes) > 0 {
+		return result.Workspace.Schemes
+	}
+	return result.Project.Schemes
 }
 
-var teamIDRegexp = regexp.MustCompile(`DEVELOPMENT_TEAM\s*=\s*([A-Z0-9]{10})\s*;`)
+// teamIDPattern is the shape of an Apple Team ID (and of an ASC Key ID).
+const teamIDPattern = `[A-Z0-9]{10}`
+
+var (
+	teamIDRegexp   = regexp.MustCompile(`DEVELOPMENT_TEAM\s*=\s*(` + teamIDPattern + `)\s*;`)
+	bundleIDRegexp = regexp.MustCompile(`PRODUCT_BUNDLE_IDENTIFIER\s*=\s*"?([^";]+?)"?\s*;`)
+)
 
-// DetectTeamID reads the first .xcodeproj/project.pbxproj under root and returns
-// the most frequently occurring DEVELOPMENT_TEAM value. Returns "", nil if not found.
+// DetectTeamID parses the projects referenced by the workspace and returns
+// the DEVELOPMENT_TEAM of the app targets' Release configuration, or the most
+// frequent team across all targets when no app target sets one. Files that
+// cannot be parsed are regex-scanned instead. Returns "", nil if not found.
 func DetectTeamID(workspacePath string) (string, error) {
-	// Find the first project.pbxproj relative to the workspace directory.
-	workspaceDir := filepath.Dir(workspacePath)
+	settings, unparsed := detectTargetSettings(workspaceProjectFiles(workspacePath))
 
-	var pbxprojPath string
-	_ = filepath.WalkDir(workspaceDir, func(path string, d fs.DirEntry, err error) error {
-		if err != nil || pbxprojPath != "" {
-			return nil
+	var appRelease, all []string
+	for _, s := range settings {
+		if s.TeamID == "" || strings.Contains(s.TeamID, "$") {
+			continue
 		}
-		if !d.IsDir() && d.Name() == "project.pbxproj" && strings.Contains(path, ".xcodeproj") {
-			pbxprojPath = path
-			return fs.SkipAll
+		all = append(all, s.TeamID)
+		if s.ProductType == pbxproj.ProductTypeApplication && s.Configuration == DefaultConfiguration {
+			appRelease = append(appRelease, s.TeamID)
 		}
-		return nil
-	})
+	}
+	for _, path := range unparsed {
+		all = append(all, scanSetting(path, teamIDRegexp)...)
+	}
 
-	if pbxprojPath == "" {
-		return "", nil
+	if len(appRelease) > 0 {
+		return mostFrequent(appRelease)[0], nil
 	}
+	if len(all) > 0 {
+		return mostFrequent(all)[0], nil
+	}
+	return "", nil
+}
 
-	content, err := os.ReadFile(pbxprojPath)
-	if err != nil {
-		return "", nil //nolint:nilerr
+// DetectBundleIDs parses every .xcodeproj/project.pbxproj under root and
+// returns the resolved PRODUCT_BUNDLE_IDENTIFIER values, most frequent first.
+// Values that still reference unknown build settings are skipped.
+func DetectBundleIDs(root string) []string {
+	return bundleIDsIn(findPbxprojFiles(root))
+}
+
+// bundleIDsIn returns the literal bundle IDs set in the given pbxproj files.
+func bundleIDsIn(files []string) []string {
+	settings, unparsed := detectTargetSettings(files)
+
+	var ids []string
+	for _, s := range settings {
+		ids = append(ids, s.BundleID)
+	}
+	for _, path := range unparsed {
+		ids = append(ids, scanSetting(path, bundleIDRegexp)...)
 	}
 
-	matches := teamIDRegexp.FindAllSubmatch(content, -1)
-	if len(matches) == 0 {
-		return "", nil
+	literal := ids[:0]
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" && !strings.Contains(id, "$") {
+			literal = append(literal, id)
+		}
 	}
+	return mostFrequent(literal)
+}
+
+// scanSetting returns every value captured by re in the file at path.
+func scanSetting(path string, re *regexp.Regexp) []string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var values []string
+	for _, m := range re.FindAllSubmatch(content, -1) {
+		values = append(values, string(m[1]))
+	}
+	return values
+}
 
-	// Return the most frequently occurring team ID.
+// mostFrequent returns the distinct values ordered by how often they occur,
+// ties broken alphabetically.
+func mostFrequent(values []string) []string {
 	counts := make(map[string]int)
-	for _, m := range matches {
-		counts[string(m[1])]++
+	for _, v := range values {
+		counts[v]++
+	}
+	distinct := make([]string, 0, len(counts))
+	for v := range counts {
+		distinct = append(distinct, v)
 	}
-	var best string
-	var bestCount int
-	for id, count := range counts {
-		if count > bestCount {
-			best = id
-			bestCount = count
+	sort.Slice(distinct, func(i, j int) bool {
+		if counts[distinct[i]] != counts[distinct[j]] {
+			return counts[distinct[i]] > counts[distinct[j]]
+		}
+		return distinct[i] < distinct[j]
+	})
+	return distinct
+}
+
+// DetectSchemeBundleID asks xcodebuild for the PRODUCT_BUNDLE_IDENTIFIER the
+// scheme's application target builds in configuration. Returns "" when
+// xcodebuild is unavailable or reports no application target.
+func DetectSchemeBundleID(workspacePath, scheme, configuration string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "xcodebuild", "-showBuildSettings", "-json",
+		ProjectKindForPath(workspacePath).xcodebuildFlag(), workspacePath, "-scheme", scheme, "-configuration", configuration)
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return parseShowBuildSettingsBundleID(out)
+}
+
+// parseShowBuildSettingsBundleID picks the application target's bundle ID from
+// `xcodebuild -showBuildSettings -json` output, falling back to the first
+// target that has one.
+func parseShowBuildSettingsBundleID(raw []byte) string {
+	var targets []struct {
+		Target        string            `json:"target"`
+		BuildSettings map[string]string `json:"buildSettings"`
+	}
+	if err := json.Unmarshal(raw, &targets); err != nil {
+		return ""
+	}
+
+	var fallback string
+	for _, target := range targets {
+		id := target.BuildSettings["PRODUCT_BUNDLE_IDENTIFIER"]
+		if id == "" {
+			continue
+		}
+		if target.BuildSettings["PRODUCT_TYPE"] == "com.apple.product-type.application" {
+			return id
+		}
+		if fallback == "" {
+			fallback = id
 		}
 	}
-	return best, nil
+	return fallback
 }
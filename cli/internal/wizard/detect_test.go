@@ -3,6 +3,7 @@ package wizard
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 )
 
@@ -114,3 +115,61 @@ func TestDetectAllWorkspaceCandidates(t *testing.T) {
 		t.Errorf("expected first candidate 'Alpha.xcworkspace', got %q", candidates[0])
 	}
 }
+
+func TestDetectAllWorkspaceCandidatesStandaloneProjects(t *testing.T) {
+	tmpDir := t.TempDir()
+	// A workspace and the project it references: only the workspace is a candidate.
+	// Every .xcodeproj embeds a project.xcworkspace, which must not be listed.
+	mkdirs(t,
+		filepath.Join(tmpDir, "App.xcodeproj", "project.xcworkspace"),
+		filepath.Join(tmpDir, "Tool", "Tool.xcodeproj", "project.xcworkspace"),
+	)
+	writeWorkspace(t, filepath.Join(tmpDir, "App.xcworkspace"), `<FileRef location = "group:App.xcodeproj"></FileRef>`)
+
+	candidates := detectAllWorkspaceCandidates(tmpDir)
+	want := []string{"App.xcworkspace", filepath.Join("Tool", "Tool.xcodeproj")}
+	if !slices.Equal(candidates, want) {
+		t.Errorf("candidates = %v, want %v", candidates, want)
+	}
+}
+
+func TestParseListSchemes(t *testing.T) {
+	cases := map[string]string{
+		"workspace": `{"workspace":{"name":"App","schemes":["App","Widget"]}}`,
+		"project":   `{"project":{"name":"App","schemes":["App","Widget"],"targets":["App"]}}`,
+	}
+	for name, raw := range cases {
+		if got := parseListSchemes([]byte(raw)); !slices.Equal(got, []string{"App", "Widget"}) {
+			t.Errorf("%s: got %v", name, got)
+		}
+	}
+}
+
+func TestDetectBundleIDs(t *testing.T) {
+	tmpDir := t.TempDir()
+	xcodeprojDir := filepath.Join(tmpDir, "ios", "MyApp.xcodeproj")
+	if err := os.MkdirAll(xcodeprojDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	pbxprojContent := `
+		PRODUCT_BUNDLE_IDENTIFIER = com.example.myapp;
+		PRODUCT_BUNDLE_IDENTIFIER = com.example.myapp;
+		PRODUCT_BUNDLE_IDENTIFIER = "com.example.myapp.widget";
+		PRODUCT_BUNDLE_IDENTIFIER = "org.cocoapods.$(PRODUCT_NAME:rfc1034identifier)";
+`
+	if err := os.WriteFile(filepath.Join(xcodeprojDir, "project.pbxproj"), []byte(pbxprojContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := DetectBundleIDs(tmpDir)
+	want := []string{"com.example.myapp", "com.example.myapp.widget"}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, ids)
+		}
+	}
+}
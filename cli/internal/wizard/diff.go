@@ -0,0 +1,161 @@
+package wizard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/term"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// UnifiedDiff returns a unified diff turning a into b, or "" when they are
+// equal. The file headers use oldName and newName.
+func UnifiedDiff(oldName, newName, a, b string) string {
+	if a == b {
+		return ""
+	}
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+	for start := 0; start < len(ops); {
+		// Find the next change and extend the hunk while the unchanged run
+		// between changes is short enough to share context.
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		last := first
+		for i := first + 1; i < len(ops); i++ {
+			if ops[i].kind == ' ' {
+				continue
+			}
+			if i-last > 2*diffContext {
+				break
+			}
+			last = i
+		}
+		from := max(first-diffContext, start)
+		to := min(last+diffContext+1, len(ops))
+
+		oldLine, newLine := 1, 1
+		for _, op := range ops[:from] {
+			if op.kind != '+' {
+				oldLine++
+			}
+			if op.kind != '-' {
+				newLine++
+			}
+		}
+		var oldCount, newCount int
+		for _, op := range ops[from:to] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount))
+		for _, op := range ops[from:to] {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.line)
+			sb.WriteByte('\n')
+		}
+		start = to
+	}
+	return sb.String()
+}
+
+// hunkRange formats the start,count pair of a hunk header. An empty range
+// points at the line before it, as diff -u does.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes a shortest edit script from the longest common
+// subsequence of a and b. Workflows are small, so the quadratic table is fine.
+func diffLines(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+// colorDiff colors the lines of a unified diff for the terminal.
+func colorDiff(theme term.Theme, diff string) string {
+	var sb strings.Builder
+	for _, line := range splitLines(diff) {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			line = theme.Label(line)
+		case strings.HasPrefix(line, "@@"):
+			line = theme.Section(line)
+		case strings.HasPrefix(line, "+"):
+			line = theme.Added(line)
+		case strings.HasPrefix(line, "-"):
+			line = theme.Removed(line)
+		default:
+			line = theme.Muted(line)
+		}
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,51 @@
+package wizard
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+	b := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nn\n"
+	want := `--- old
++++ new
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -11,3 +11,4 @@
+ k
+ l
+ m
++n
+`
+	if got := UnifiedDiff("old", "new", a, b); got != want {
+		t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnifiedDiffMergesNearbyChanges(t *testing.T) {
+	want := `--- old
++++ new
+@@ -1,3 +1,3 @@
+-a
++A
+ b
+-c
++C
+`
+	if got := UnifiedDiff("old", "new", "a\nb\nc\n", "A\nb\nC\n"); got != want {
+		t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnifiedDiffEqualAndEmpty(t *testing.T) {
+	if got := UnifiedDiff("old", "new", "a\n", "a\n"); got != "" {
+		t.Errorf("expected no diff for equal content, got %q", got)
+	}
+	want := "--- old\n+++ new\n@@ -0,0 +1 @@\n+a\n"
+	if got := UnifiedDiff("old", "new", "", "a\n"); got != want {
+		t.Errorf("UnifiedDiff() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,147 @@
+package wizard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/term"
+)
+
+// Doctor probes local tools, the .p8 key and App Store Connect access, and
+// prints each finding with a remediation hint. The bundle ID defaults to the
+// one in the project config when not passed explicitly, and the config's
+// scheme is checked for being shared.
+// Returns an error when at least one probe fails.
+func Doctor(out io.Writer, opts Options) error {
+	theme := term.NewTheme()
+
+	fmt.Fprintln(out, theme.Title("ReleaseKit-iOS Doctor"))
+	fmt.Fprintln(out, theme.Muted("Diagnoses the local setup and App Store Connect access."))
+	fmt.Fprintln(out)
+
+	results := []CheckResult{
+		toolCheck("asc", []string{"--version"}, CheckWarn, "Optional locally (the upload action installs it on CI): brew install rudrankriyam/tap/asc"),
+		toolCheck("xcodebuild", []string{"-version"}, CheckWarn, "Install Xcode, then run: xcode-select --install"),
+		toolCheck("gh", []string{"--version"}, CheckWarn, "Install it with: brew install gh"),
+	}
+	if commandExists("gh") {
+		if ghIsAuthenticated() {
+			results = append(results, CheckResult{Name: "gh auth", Status: CheckPass, Detail: "authenticated"})
+		} else {
+			results = append(results, CheckResult{Name: "gh auth", Status: CheckWarn, Detail: "not authenticated", Hint: "Run: gh auth login"})
+		}
+	}
+
+	saved, _ := LoadConfig(DefaultConfigPath)
+	bundleID := strings.TrimSpace(opts.BundleID)
+	if bundleID == "" {
+		bundleID = saved.BundleID
+	}
+	if project := saved.Inputs(); project.Workspace != "" && project.Scheme != "" {
+		results = append(results, checkSchemeShared(project.Workspace, project.Scheme))
+	}
+
+	privKeyB64, keyErr := resolvePrivateKey(opts.P8Path, opts.P8B64)
+	keyResult := checkPrivateKey(privKeyB64, keyErr)
+	results = append(results, keyResult)
+
+	keyID := strings.TrimSpace(opts.ASCKeyID)
+	issuerID := strings.TrimSpace(opts.ASCIssuerID)
+	switch {
+	case keyResult.Status != CheckPass || keyID == "" || issuerID == "":
+		results = append(results, CheckResult{
+			Name:   "ASC credentials",
+			Status: CheckWarn,
+			Detail: "not checked",
+			Hint:   fmt.Sprintf("Pass --asc-key-id, --asc-issuer-id and a valid key (or set %s, %s)", EnvVarForFlag("asc-key-id"), EnvVarForFlag("asc-issuer-id")),
+		})
+	default:
+		apps, err := ListASCApps(keyID, issuerID, privKeyB64)
+		if err != nil {
+			results = append(results, CheckResult{
+				Name:   "ASC credentials",
+				Status: CheckFail,
+				Detail: firstLine(err.Error()),
+				Hint:   "Check the Key ID and Issuer ID under App Store Connect → Users and Access → Integrations; the key needs the Developer role or higher",
+			})
+		} else {
+			results = append(results, CheckResult{Name: "ASC credentials", Status: CheckPass, Detail: fmt.Sprintf("valid (%d apps visible)", len(apps))})
+			results = append(results, checkBundleIDInApps(bundleID, apps))
+		}
+	}
+
+	failures := printCheckResults(out, theme, results)
+	if failures > 0 {
+		return fmt.Errorf("doctor found %d problem(s)", failures)
+	}
+	return nil
+}
+
+// toolCheck reports whether name is on PATH along with its version line.
+func toolCheck(name string, versionArgs []string, missingStatus CheckStatus, hint string) CheckResult {
+	if !commandExists(name) {
+		return CheckResult{Name: name, Status: missingStatus, Detail: "not found", Hint: hint}
+	}
+	version := toolVersion(name, versionArgs...)
+	if version == "" {
+		version = "installed (version unknown)"
+	}
+	return CheckResult{Name: name, Status: CheckPass, Detail: version}
+}
+
+// toolVersion runs `name args...` and returns the first line of its output.
+func toolVersion(name string, args ...string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return ""
+	}
+	return firstLine(string(out))
+}
+
+func firstLine(value string) string {
+	value = strings.TrimSpace(value)
+	if idx := strings.IndexByte(value, '\n'); idx >= 0 {
+		value = value[:idx]
+	}
+	return strings.TrimSpace(value)
+}
+
+func checkPrivateKey(privKeyB64 string, resolveErr error) CheckResult {
+	name := "Private key"
+	hint := "Download a new key from App Store Connect → Users and Access → Integrations and encode it with: base64 < AuthKey_XXXX.p8 | tr -d '\\n'"
+	switch {
+	case resolveErr != nil:
+		return CheckResult{Name: name, Status: CheckFail, Detail: resolveErr.Error(), Hint: "Pass the path to an existing AuthKey_XXXX.p8 file"}
+	case privKeyB64 == "":
+		return CheckResult{Name: name, Status: CheckWarn, Detail: "not provided", Hint: fmt.Sprintf("Pass --p8-path or set %s", EnvVarForFlag("p8-path"))}
+	}
+	if _, err := parseP8PrivateKey(privKeyB64); err != nil {
+		return CheckResult{Name: name, Status: CheckFail, Detail: err.Error(), Hint: hint}
+	}
+	return CheckResult{Name: name, Status: CheckPass, Detail: "EC P-256 PKCS#8 key"}
+}
+
+func checkBundleIDInApps(bundleID string, apps []ASCApp) CheckResult {
+	name := "Bundle ID"
+	if bundleID == "" {
+		return CheckResult{Name: name, Status: CheckWarn, Detail: "not checked", Hint: fmt.Sprintf("Pass --bundle-id or add bundle_id to %s", DefaultConfigPath)}
+	}
+	for _, app := range apps {
+		if app.Attributes.BundleID == bundleID {
+			return CheckResult{Name: name, Status: CheckPass, Detail: fmt.Sprintf("%s → %s (%s)", bundleID, app.Attributes.Name, app.ID)}
+		}
+	}
+	return CheckResult{
+		Name:   name,
+		Status: CheckFail,
+		Detail: bundleID + " not found in App Store Connect",
+		Hint:   "Create the app in App Store Connect, or fix the BUNDLE_ID variable; the API key must have access to the app",
+	}
+}
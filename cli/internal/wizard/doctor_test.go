@@ -0,0 +1,87 @@
+package wizard
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testP8KeyB64 returns a freshly generated .p8-style key, base64 encoded.
+func testP8KeyB64(t *testing.T, curve elliptic.Curve) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	return base64.StdEncoding.EncodeToString(pemBytes)
+}
+
+func TestCheckPrivateKeyValid(t *testing.T) {
+	result := checkPrivateKey(testP8KeyB64(t, elliptic.P256()), nil)
+	if result.Status != CheckPass {
+		t.Fatalf("expected pass, got %s: %s", result.Status, result.Detail)
+	}
+}
+
+func TestCheckPrivateKeyWrongCurve(t *testing.T) {
+	result := checkPrivateKey(testP8KeyB64(t, elliptic.P384()), nil)
+	if result.Status != CheckFail || !strings.Contains(result.Detail, "P-256") {
+		t.Fatalf("expected P-256 failure, got %s: %s", result.Status, result.Detail)
+	}
+	if result.Hint == "" {
+		t.Error("expected a remediation hint")
+	}
+}
+
+func TestCheckPrivateKeyNotPEM(t *testing.T) {
+	result := checkPrivateKey(base64.StdEncoding.EncodeToString([]byte("private-key")), nil)
+	if result.Status != CheckFail || !strings.Contains(result.Detail, "PEM") {
+		t.Fatalf("expected PEM failure, got %s: %s", result.Status, result.Detail)
+	}
+}
+
+func TestCheckPrivateKeyMissingAndUnreadable(t *testing.T) {
+	if got := checkPrivateKey("", nil).Status; got != CheckWarn {
+		t.Errorf("expected warn when no key is provided, got %s", got)
+	}
+	if got := checkPrivateKey("", errors.New("p8 file not found")).Status; got != CheckFail {
+		t.Errorf("expected fail when the key cannot be read, got %s", got)
+	}
+}
+
+func TestCheckBundleIDInApps(t *testing.T) {
+	apps := testASCApps()
+	if got := checkBundleIDInApps("com.example.two", apps); got.Status != CheckPass || !strings.Contains(got.Detail, "App Two") {
+		t.Errorf("expected pass mentioning App Two, got %+v", got)
+	}
+	if got := checkBundleIDInApps("com.example.missing", apps); got.Status != CheckFail || got.Hint == "" {
+		t.Errorf("expected fail with hint, got %+v", got)
+	}
+	if got := checkBundleIDInApps("", apps).Status; got != CheckWarn {
+		t.Errorf("expected warn without bundle ID, got %s", got)
+	}
+}
+
+func TestToolCheckMissing(t *testing.T) {
+	result := toolCheck("releasekit-definitely-missing-tool", nil, CheckFail, "install it")
+	if result.Status != CheckFail || result.Hint != "install it" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestFirstLine(t *testing.T) {
+	if got := firstLine("  Xcode 16.2\nBuild version 16C5032a\n"); got != "Xcode 16.2" {
+		t.Errorf("firstLine() = %q", got)
+	}
+}
@@ -0,0 +1,240 @@
+package wizard
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/plist"
+	"github.com/vinceglb/releasekit-ios/cli/internal/term"
+	"gopkg.in/yaml.v3"
+)
+
+// ExportOptionsConfig is the export_options section of the project config.
+// Unset fields keep xcodebuild's defaults.
+type ExportOptionsConfig struct {
+	Method                         string            `yaml:"method,omitempty"`
+	SigningStyle                   string            `yaml:"signing_style,omitempty"`
+	SigningCertificate             string            `yaml:"signing_certificate,omitempty"`
+	ManageAppVersionAndBuildNumber *bool             `yaml:"manage_app_version_and_build_number,omitempty"`
+	UploadSymbols                  *bool             `yaml:"upload_symbols,omitempty"`
+	TestFlightInternalTestingOnly  *bool             `yaml:"testflight_internal_testing_only,omitempty"`
+	ProvisioningProfiles           map[string]string `yaml:"provisioning_profiles,omitempty"` // bundle ID -> profile name
+}
+
+// ExportOptions is the ExportOptions.plist handed to xcodebuild -exportArchive.
+type ExportOptions struct {
+	Method                         string            `plist:"method"`
+	SigningStyle                   string            `plist:"signingStyle"`
+	SigningCertificate             string            `plist:"signingCertificate,omitempty"`
+	TeamID                         string            `plist:"teamID"`
+	ProvisioningProfiles           map[string]string `plist:"provisioningProfiles,omitempty"`
+	ManageAppVersionAndBuildNumber *bool             `plist:"manageAppVersionAndBuildNumber"`
+	UploadSymbols                  *bool             `plist:"uploadSymbols"`
+	TestFlightInternalTestingOnly  *bool             `plist:"testFlightInternalTestingOnly"`
+}
+
+const (
+	DefaultExportMethod       = "app-store-connect"
+	DefaultExportSigningStyle = "automatic"
+)
+
+// exportMethods are the iOS export methods xcodebuild accepts, including the
+// names used before Xcode 15.3.
+var exportMethods = []string{"app-store-connect", "release-testing", "enterprise", "debugging", "app-store", "ad-hoc", "development"}
+
+// appStoreMethod reports whether method uploads to App Store Connect, the
+// only case where the TestFlight and version options apply.
+func appStoreMethod(method string) bool {
+	return method == "app-store-connect" || method == "app-store"
+}
+
+// BuildExportOptions resolves the export options for cfg, filling defaults
+// that match the archive action's built-in plist, and validates them.
+func BuildExportOptions(cfg Config) (ExportOptions, error) {
+	var section ExportOptionsConfig
+	if cfg.ExportOptions != nil {
+		section = *cfg.ExportOptions
+	}
+	opts := ExportOptions{
+		Method:                         section.Method,
+		SigningStyle:                   section.SigningStyle,
+		SigningCertificate:             section.SigningCertificate,
+		TeamID:                         cfg.TeamID,
+		ProvisioningProfiles:           section.ProvisioningProfiles,
+		ManageAppVersionAndBuildNumber: section.ManageAppVersionAndBuildNumber,
+		UploadSymbols:                  section.UploadSymbols,
+		TestFlightInternalTestingOnly:  section.TestFlightInternalTestingOnly,
+	}
+	if opts.Method == "" {
+		opts.Method = DefaultExportMethod
+	}
+	if opts.SigningStyle == "" {
+		opts.SigningStyle = DefaultExportSigningStyle
+	}
+	if opts.SigningCertificate == "" {
+		switch opts.Method {
+		case "debugging", "development":
+			opts.SigningCertificate = "Apple Development"
+		default:
+			opts.SigningCertificate = "Apple Distribution"
+		}
+	}
+	return opts, validateExportOptions(opts, cfg.BundleID)
+}
+
+func validateExportOptions(opts ExportOptions, bundleID string) error {
+	if !slices.Contains(exportMethods, opts.Method) {
+		return fmt.Errorf("export method %q is not one of: %s", opts.Method, strings.Join(exportMethods, ", "))
+	}
+	if err := validateTeamID(opts.TeamID); err != nil {
+		return err
+	}
+	switch opts.SigningStyle {
+	case "automatic":
+	case "manual":
+		if len(opts.ProvisioningProfiles) == 0 {
+			return fmt.Errorf("manual signing needs provisioning_profiles (bundle ID -> profile name)")
+		}
+		if bundleID != "" {
+			if _, ok := opts.ProvisioningProfiles[bundleID]; !ok {
+				return fmt.Errorf("provisioning_profiles has no profile for the app's bundle ID %s", bundleID)
+			}
+		}
+	default:
+		return fmt.Errorf("signing style %q must be automatic or manual", opts.SigningStyle)
+	}
+	for id, profile := range opts.ProvisioningProfiles {
+		if err := validateBundleID(id); err != nil {
+			return fmt.Errorf("provisioning_profiles: %w", err)
+		}
+		if strings.TrimSpace(profile) == "" {
+			return fmt.Errorf("provisioning_profiles: profile for %s must not be empty", id)
+		}
+	}
+	if !appStoreMethod(opts.Method) {
+		for _, option := range []struct {
+			name  string
+			value *bool
+		}{
+			{"manage_app_version_and_build_number", opts.ManageAppVersionAndBuildNumber},
+			{"upload_symbols", opts.UploadSymbols},
+			{"testflight_internal_testing_only", opts.TestFlightInternalTestingOnly},
+		} {
+			if option.value != nil {
+				return fmt.Errorf("%s only applies to the app-store-connect method, not %s", option.name, opts.Method)
+			}
+		}
+	}
+	return nil
+}
+
+// RenderExportOptions renders cfg's export options as an XML plist.
+func RenderExportOptions(cfg Config) ([]byte, error) {
+	opts, err := BuildExportOptions(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid export options: %w", err)
+	}
+	return plist.Marshal(opts)
+}
+
+// ExportOptionsCommandOptions are the flags of `releasekit-ios export-options`.
+type ExportOptionsCommandOptions struct {
+	ConfigPath string
+	Output     string // "-" for stdout; defaults to the config's export_options_path
+}
+
+// WriteExportOptions renders the ExportOptions.plist described by the project
+// config to opts.Output, the config's export_options_path, or stdout.
+func WriteExportOptions(out io.Writer, opts ExportOptionsCommandOptions) error {
+	cfg, err := LoadConfig(opts.ConfigPath)
+	if err != nil {
+		return err
+	}
+	content, err := RenderExportOptions(cfg)
+	if err != nil {
+		return err
+	}
+
+	output := opts.Output
+	if output == "" {
+		output = cfg.ExportOptionsPath
+	}
+	if output == "" || output == "-" {
+		_, err := out.Write(content)
+		return err
+	}
+	if dir := filepath.Dir(output); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	if err := os.WriteFile(output, content, 0644); err != nil {
+		return err
+	}
+	theme := term.NewTheme()
+	fmt.Fprintf(out, "%s Wrote %s\n", theme.Success("✓"), output)
+	if cfg.ExportOptionsPath != output {
+		fmt.Fprintf(out, "  %s\n", theme.Muted("Set export_options_path: "+output+" in "+opts.ConfigPath+" so the workflow passes it to the archive action."))
+	}
+	return nil
+}
+
+// parseExportOptionsNode reads the export_options mapping, reporting unknown
+// keys and wrong types through issue.
+func parseExportOptionsNode(node *yaml.Node, issue func(line int, format string, args ...any)) *ExportOptionsConfig {
+	if node.Kind != yaml.MappingNode {
+		issue(node.Line, "export_options must be a mapping")
+		return nil
+	}
+	section := &ExportOptionsConfig{}
+	stringFields := map[string]*string{
+		"method":              &section.Method,
+		"signing_style":       &section.SigningStyle,
+		"signing_certificate": &section.SigningCertificate,
+	}
+	boolFields := map[string]**bool{
+		"manage_app_version_and_build_number": &section.ManageAppVersionAndBuildNumber,
+		"upload_symbols":                      &section.UploadSymbols,
+		"testflight_internal_testing_only":    &section.TestFlightInternalTestingOnly,
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		name := "export_options." + key.Value
+		switch {
+		case stringFields[key.Value] != nil:
+			if value.Kind != yaml.ScalarNode || value.Tag == "!!null" {
+				issue(value.Line, "%s must be a string", name)
+				continue
+			}
+			*stringFields[key.Value] = strings.TrimSpace(value.Value)
+		case boolFields[key.Value] != nil:
+			var b bool
+			if value.Kind != yaml.ScalarNode || value.Tag != "!!bool" || value.Decode(&b) != nil {
+				issue(value.Line, "%s must be true or false", name)
+				continue
+			}
+			*boolFields[key.Value] = &b
+		case key.Value == "provisioning_profiles":
+			if value.Kind != yaml.MappingNode {
+				issue(value.Line, "%s must be a mapping of bundle ID to profile name", name)
+				continue
+			}
+			section.ProvisioningProfiles = make(map[string]string)
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				id, profile := value.Content[j], value.Content[j+1]
+				if profile.Kind != yaml.ScalarNode {
+					issue(profile.Line, "%s.%s must be a profile name", name, id.Value)
+					continue
+				}
+				section.ProvisioningProfiles[id.Value] = profile.Value
+			}
+		default:
+			issue(key.Line, "unknown key %q", name)
+		}
+	}
+	return section
+}
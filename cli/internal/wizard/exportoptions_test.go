@@ -0,0 +1,125 @@
+package wizard
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const exportOptionsConfig = `version: 1
+workspace: App.xcworkspace
+scheme: App
+bundle_id: com.example.app
+team_id: ABCDE12345
+app_id: "123456789"
+export_options_path: ci/ExportOptions.plist
+export_options:
+  signing_style: manual
+  upload_symbols: false
+  testflight_internal_testing_only: true
+  provisioning_profiles:
+    com.example.app: App Store Profile
+    com.example.app.Widget: Widget & Co
+`
+
+func TestRenderExportOptions(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, exportOptionsConfig))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	content, err := RenderExportOptions(cfg)
+	if err != nil {
+		t.Fatalf("RenderExportOptions: %v", err)
+	}
+	body := string(content)
+	for _, want := range []string{
+		"<key>method</key>\n\t<string>app-store-connect</string>",
+		"<key>signingStyle</key>\n\t<string>manual</string>",
+		"<key>signingCertificate</key>\n\t<string>Apple Distribution</string>",
+		"<key>teamID</key>\n\t<string>ABCDE12345</string>",
+		"<key>com.example.app.Widget</key>\n\t\t<string>Widget &amp; Co</string>",
+		"<key>uploadSymbols</key>\n\t<false/>",
+		"<key>testFlightInternalTestingOnly</key>\n\t<true/>",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "manageAppVersionAndBuildNumber") {
+		t.Errorf("unset options should be omitted:\n%s", body)
+	}
+}
+
+func TestRenderExportOptionsDefaults(t *testing.T) {
+	content, err := RenderExportOptions(Config{TeamID: "ABCDE12345", BundleID: "com.example.app"})
+	if err != nil {
+		t.Fatalf("RenderExportOptions: %v", err)
+	}
+	for _, want := range []string{"app-store-connect", "automatic", "Apple Distribution"} {
+		if !strings.Contains(string(content), "<string>"+want+"</string>") {
+			t.Errorf("expected default %q in:\n%s", want, content)
+		}
+	}
+}
+
+func TestBuildExportOptionsValidation(t *testing.T) {
+	yes := true
+	tests := []struct {
+		name    string
+		options ExportOptionsConfig
+		want    string
+	}{
+		{"unknown method", ExportOptionsConfig{Method: "store"}, `export method "store"`},
+		{"bad signing style", ExportOptionsConfig{SigningStyle: "auto"}, `signing style "auto"`},
+		{"manual without profiles", ExportOptionsConfig{SigningStyle: "manual"}, "manual signing needs provisioning_profiles"},
+		{"manual without app profile", ExportOptionsConfig{SigningStyle: "manual", ProvisioningProfiles: map[string]string{"com.example.other": "P"}}, "no profile for the app's bundle ID"},
+		{"app store option on ad-hoc", ExportOptionsConfig{Method: "release-testing", UploadSymbols: &yes}, "upload_symbols only applies"},
+	}
+	for _, tc := range tests {
+		cfg := Config{TeamID: "ABCDE12345", BundleID: "com.example.app", ExportOptions: &tc.options}
+		if _, err := BuildExportOptions(cfg); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestLoadConfigReportsExportOptionsProblems(t *testing.T) {
+	path := writeConfig(t, `version: 1
+workspace: App.xcworkspace
+scheme: App
+bundle_id: com.example.app
+team_id: ABCDE12345
+app_id: "1"
+export_options:
+  methd: ad-hoc
+  upload_symbols: "no"
+`)
+	_, err := LoadConfig(path)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{`:8: unknown key "export_options.methd"`, ":9: export_options.upload_symbols must be true or false"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in: %v", want, err)
+		}
+	}
+}
+
+func TestWriteExportOptionsUsesConfiguredPath(t *testing.T) {
+	configPath := writeConfig(t, exportOptionsConfig)
+	t.Chdir(filepath.Dir(configPath))
+
+	var out bytes.Buffer
+	if err := WriteExportOptions(&out, ExportOptionsCommandOptions{ConfigPath: configPath}); err != nil {
+		t.Fatalf("WriteExportOptions: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join("ci", "ExportOptions.plist"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), "<?xml") {
+		t.Errorf("unexpected plist:\n%s", content)
+	}
+}
@@ -0,0 +1,169 @@
+package wizard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/ascapi"
+	"github.com/vinceglb/releasekit-ios/cli/internal/pbxproj"
+	"github.com/vinceglb/releasekit-ios/cli/internal/term"
+)
+
+// RegistrationStatus says whether a bundle ID is registered in App Store
+// Connect.
+type RegistrationStatus int
+
+const (
+	RegistrationUnchecked RegistrationStatus = iota
+	RegistrationFound
+	RegistrationMissing
+)
+
+// EmbeddedTarget is an extension, App Clip or watch app that ships inside the
+// app. Each one needs its own registered bundle ID and provisioning profile,
+// or cloud signing fails at export time.
+type EmbeddedTarget struct {
+	Target      string
+	ProductType string
+	BundleID    string
+	Registered  RegistrationStatus
+}
+
+// DetectEmbeddedTargets finds the app target the scheme archives and returns
+// the targets it embeds, with their bundle IDs in the build configuration.
+// The app target is the scheme's buildable, else the one building
+// inputs.BundleID, else the only app target.
+func DetectEmbeddedTargets(inputs Inputs) []EmbeddedTarget {
+	configuration := inputs.Configuration
+	if configuration == "" {
+		configuration = DefaultConfiguration
+	}
+	var blueprint string
+	if scheme, ok := findSchemeFile(inputs.Workspace, inputs.Scheme); ok {
+		blueprint = scheme.BlueprintName
+	}
+
+	type candidate struct {
+		project *pbxproj.Project
+		app     pbxproj.Target
+	}
+	var apps []candidate
+	for _, path := range workspaceProjectFiles(inputs.Workspace) {
+		project, err := pbxproj.ParseFile(path)
+		if err != nil {
+			continue
+		}
+		for _, app := range project.ApplicationTargets() {
+			apps = append(apps, candidate{project, app})
+		}
+	}
+
+	pick := func(match func(candidate) bool) (candidate, bool) {
+		for _, c := range apps {
+			if match(c) {
+				return c, true
+			}
+		}
+		return candidate{}, false
+	}
+	chosen, ok := pick(func(c candidate) bool { return blueprint != "" && c.app.Name == blueprint })
+	if !ok {
+		chosen, ok = pick(func(c candidate) bool {
+			return c.project.Setting(c.app, configuration, "PRODUCT_BUNDLE_IDENTIFIER") == inputs.BundleID
+		})
+	}
+	if !ok && len(apps) == 1 {
+		chosen, ok = apps[0], true
+	}
+	if !ok {
+		return nil
+	}
+
+	var embedded []EmbeddedTarget
+	for _, target := range chosen.project.EmbeddedTargets(chosen.app) {
+		embedded = append(embedded, EmbeddedTarget{
+			Target:      target.Name,
+			ProductType: target.ProductType,
+			BundleID:    chosen.project.Setting(target, configuration, "PRODUCT_BUNDLE_IDENTIFIER"),
+		})
+	}
+	return embedded
+}
+
+// checkEmbeddedBundleIDs looks up each embedded bundle ID in App Store
+// Connect and records whether it is registered. Unresolved IDs (still holding
+// a $(VAR) reference) stay unchecked.
+func checkEmbeddedBundleIDs(keyID, issuerID, privKeyB64 string, targets []EmbeddedTarget, opts ...ascapi.Option) error {
+	var identifiers []string
+	for _, target := range targets {
+		if target.BundleID != "" && !strings.Contains(target.BundleID, "$") {
+			identifiers = append(identifiers, target.BundleID)
+		}
+	}
+	if len(identifiers) == 0 {
+		return nil
+	}
+
+	client, err := newASCClient(keyID, issuerID, privKeyB64, opts...)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	registered, err := client.ListBundleIDs(ctx, identifiers...)
+	if err != nil {
+		return fmt.Errorf("listing App Store Connect bundle IDs failed: %w", err)
+	}
+
+	found := make(map[string]bool, len(registered))
+	for _, id := range registered {
+		found[id.Attributes.Identifier] = true
+	}
+	for i, target := range targets {
+		switch {
+		case target.BundleID == "" || strings.Contains(target.BundleID, "$"):
+			continue
+		case found[target.BundleID]:
+			targets[i].Registered = RegistrationFound
+		default:
+			targets[i].Registered = RegistrationMissing
+		}
+	}
+	return nil
+}
+
+// detectExtensions fills inputs.Extensions and checks their bundle IDs
+// against App Store Connect. A failed lookup leaves them unchecked and is
+// returned for the caller to report.
+func detectExtensions(inputs *Inputs) error {
+	inputs.Extensions = DetectEmbeddedTargets(*inputs)
+	return checkEmbeddedBundleIDs(inputs.ASCKeyID, inputs.ASCIssuerID, inputs.ASCPrivateKeyB64, inputs.Extensions)
+}
+
+// unregisteredExtensions returns the embedded bundle IDs App Store Connect
+// does not know.
+func unregisteredExtensions(extensions []EmbeddedTarget) []string {
+	var ids []string
+	for _, ext := range extensions {
+		if ext.Registered == RegistrationMissing {
+			ids = append(ids, ext.BundleID)
+		}
+	}
+	return ids
+}
+
+// reportExtensions warns about embedded bundle IDs that are not registered,
+// or that could not be checked.
+func reportExtensions(out io.Writer, theme term.Theme, inputs Inputs, checkErr error) {
+	if checkErr != nil && len(inputs.Extensions) > 0 {
+		fmt.Fprintf(out, "%s Could not check extension bundle IDs: %v\n\n", theme.Muted("○"), checkErr)
+	}
+	if missing := unregisteredExtensions(inputs.Extensions); len(missing) > 0 {
+		fmt.Fprintf(out, "%s Not registered in App Store Connect: %s\n  %s\n\n",
+			theme.Failure("!"), strings.Join(missing, ", "),
+			theme.Muted("→ Register them under Certificates, Identifiers & Profiles → Identifiers, or signing fails on CI"))
+	}
+}
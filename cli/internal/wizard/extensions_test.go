@@ -0,0 +1,69 @@
+package wizard
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/term"
+)
+
+func TestDetectEmbeddedTargets(t *testing.T) {
+	root := t.TempDir()
+	writeFixtureProject(t, root)
+
+	inputs := Inputs{Workspace: filepath.Join(root, "App.xcodeproj"), Scheme: "App", BundleID: "com.example.app"}
+	embedded := DetectEmbeddedTargets(inputs)
+	if len(embedded) != 1 {
+		t.Fatalf("expected 1 embedded target, got %+v", embedded)
+	}
+	if embedded[0].Target != "Widget" || embedded[0].BundleID != "com.example.app.Widget" {
+		t.Errorf("unexpected embedded target: %+v", embedded[0])
+	}
+}
+
+func TestCheckEmbeddedBundleIDs(t *testing.T) {
+	server := withASCServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/bundleIds" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"data":[{"id":"B1","attributes":{"identifier":"com.example.app.Widget"}},{"id":"B2","attributes":{"identifier":"com.example.app.WidgetExtra"}}]}`)
+	})
+
+	targets := []EmbeddedTarget{
+		{Target: "Widget", BundleID: "com.example.app.Widget"},
+		{Target: "Notifications", BundleID: "com.example.app.Notifications"},
+		{Target: "Watch", BundleID: "$(BASE_ID).watch"},
+	}
+	if err := checkEmbeddedBundleIDs("KEYID12345", "issuer", testP8KeyB64(t, elliptic.P256()), targets, server); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []RegistrationStatus{RegistrationFound, RegistrationMissing, RegistrationUnchecked}
+	for i, target := range targets {
+		if target.Registered != want[i] {
+			t.Errorf("%s: status %v, want %v", target.Target, target.Registered, want[i])
+		}
+	}
+	if got := unregisteredExtensions(targets); len(got) != 1 || got[0] != "com.example.app.Notifications" {
+		t.Errorf("unregisteredExtensions() = %v", got)
+	}
+}
+
+func TestPrintSummaryListsEmbeddedTargets(t *testing.T) {
+	inputs := Inputs{Extensions: []EmbeddedTarget{
+		{Target: "Widget", BundleID: "com.example.app.Widget", Registered: RegistrationFound},
+		{Target: "Notifications", BundleID: "com.example.app.Notifications", Registered: RegistrationMissing},
+	}}
+
+	var buf bytes.Buffer
+	printSummary(&buf, term.NewTheme(), inputs, false)
+	for _, want := range []string{"com.example.app.Widget", "Register com.example.app.Notifications in App Store Connect"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in summary:\n%s", want, buf.String())
+		}
+	}
+}
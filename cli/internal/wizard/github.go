@@ -1,9 +1,14 @@
 package wizard
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/ghapi"
 )
 
 // DetectGitRepo detects the GitHub owner and repo from the git remote.
@@ -67,9 +72,47 @@ func IsGHAuthenticated() bool {
 	return cmd.Run() == nil
 }
 
-// SetGitHubSecrets sets GitHub secrets for the given repo using the gh CLI.
-// Returns a map of secret name to error (nil if set successfully).
-func SetGitHubSecrets(repoSlug string, secrets map[string]string) map[string]error {
+// canSetGitHubSettings reports whether secrets and variables can be set,
+// either through the REST API with a token from GITHUB_TOKEN, GH_TOKEN or the
+// gh config, or through an authenticated gh CLI.
+func canSetGitHubSettings(ghAuthed bool) bool {
+	token, _ := ghapi.ResolveToken()
+	return token != "" || ghAuthed
+}
+
+// SetGitHubSecrets sets GitHub secrets for the given repo. It uses the REST
+// API when a token is available and falls back to the gh CLI otherwise; opts
+// configure the API client. Returns a map of secret name to error (nil if set successfully).
+func SetGitHubSecrets(repoSlug string, secrets map[string]string, opts ...ghapi.Option) map[string]error {
+	if token, _ := ghapi.ResolveToken(); token != "" {
+		return setGitHubSecretsAPI(token, repoSlug, secrets, opts...)
+	}
+	return setGitHubSecretsGH(repoSlug, secrets)
+}
+
+// setGitHubSecretsAPI seals each value with the repository public key and
+// uploads it through the Actions secrets API.
+func setGitHubSecretsAPI(token, repoSlug string, secrets map[string]string, opts ...ghapi.Option) map[string]error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	client := ghapi.NewClient(token, opts...)
+	results := make(map[string]error, len(secrets))
+	key, err := client.RepoPublicKey(ctx, repoSlug)
+	if err != nil {
+		for name := range secrets {
+			results[name] = fmt.Errorf("fetching repository public key failed: %w", err)
+		}
+		return results
+	}
+	for name, value := range secrets {
+		results[name] = client.SetRepoSecret(ctx, repoSlug, key, name, value)
+	}
+	return results
+}
+
+// setGitHubSecretsGH sets each secret with `gh secret set`.
+func setGitHubSecretsGH(repoSlug string, secrets map[string]string) map[string]error {
 	results := make(map[string]error, len(secrets))
 	for name, value := range secrets {
 		cmd := exec.Command("gh", "secret", "set",
@@ -81,3 +124,119 @@ func SetGitHubSecrets(repoSlug string, secrets map[string]string) map[string]err
 	}
 	return results
 }
+
+// ListGitHubSecretNames returns the names of the Actions secrets on repoSlug.
+// Like SetGitHubSecrets it prefers the REST API and falls back to the gh CLI.
+func ListGitHubSecretNames(repoSlug string, opts ...ghapi.Option) ([]string, error) {
+	if token, _ := ghapi.ResolveToken(); token != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		names, err := ghapi.NewClient(token, opts...).ListRepoSecrets(ctx, repoSlug)
+		if err != nil {
+			return nil, fmt.Errorf("listing GitHub secrets failed: %w", err)
+		}
+		return names, nil
+	}
+
+	cmd := exec.Command("gh", "secret", "list", "--repo", repoSlug, "--json", "name")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("gh secret list failed: %w", err)
+	}
+	entries, err := parseGHListOutput(out)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	return names, nil
+}
+
+// ListGitHubVariables returns the Actions variables on repoSlug keyed by name.
+// Like SetGitHubVariables it prefers the REST API and falls back to the gh CLI.
+func ListGitHubVariables(repoSlug string, opts ...ghapi.Option) (map[string]string, error) {
+	if token, _ := ghapi.ResolveToken(); token != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		variables, err := ghapi.NewClient(token, opts...).ListRepoVariables(ctx, repoSlug)
+		if err != nil {
+			return nil, fmt.Errorf("listing GitHub variables failed: %w", err)
+		}
+		vars := make(map[string]string, len(variables))
+		for _, variable := range variables {
+			vars[variable.Name] = variable.Value
+		}
+		return vars, nil
+	}
+
+	cmd := exec.Command("gh", "variable", "list", "--repo", repoSlug, "--json", "name,value")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("gh variable list failed: %w", err)
+	}
+	entries, err := parseGHListOutput(out)
+	if err != nil {
+		return nil, err
+	}
+	vars := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		vars[entry.Name] = entry.Value
+	}
+	return vars, nil
+}
+
+type ghListEntry struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// parseGHListOutput parses the JSON array printed by `gh secret|variable list --json`.
+func parseGHListOutput(raw []byte) ([]ghListEntry, error) {
+	raw = []byte(strings.TrimSpace(string(raw)))
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var entries []ghListEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse gh output: %w", err)
+	}
+	return entries, nil
+}
+
+// SetGitHubVariables creates or updates Actions variables for the given repo.
+// Like SetGitHubSecrets it prefers the REST API and falls back to the gh CLI.
+// Returns a map of variable name to error (nil if set successfully).
+func SetGitHubVariables(repoSlug string, variables map[string]string, opts ...ghapi.Option) map[string]error {
+	if token, _ := ghapi.ResolveToken(); token != "" {
+		return setGitHubVariablesAPI(token, repoSlug, variables, opts...)
+	}
+	return setGitHubVariablesGH(repoSlug, variables)
+}
+
+func setGitHubVariablesAPI(token, repoSlug string, variables map[string]string, opts ...ghapi.Option) map[string]error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	client := ghapi.NewClient(token, opts...)
+	results := make(map[string]error, len(variables))
+	for name, value := range variables {
+		results[name] = client.SetRepoVariable(ctx, repoSlug, name, value)
+	}
+	return results
+}
+
+// setGitHubVariablesGH sets each variable with `gh variable set`.
+func setGitHubVariablesGH(repoSlug string, variables map[string]string) map[string]error {
+	results := make(map[string]error, len(variables))
+	for name, value := range variables {
+		cmd := exec.Command("gh", "variable", "set",
+			"--repo", repoSlug,
+			name,
+			"--body", value,
+		)
+		results[name] = cmd.Run()
+	}
+	return results
+}
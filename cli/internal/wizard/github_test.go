@@ -1,6 +1,20 @@
 package wizard
 
-import "testing"
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/ghapi"
+)
 
 func TestParseGitRemoteSSH(t *testing.T) {
 	owner, repo, err := parseGitRemote("git@github.com:vinceglb/releasekit-ios.git")
@@ -73,3 +87,112 @@ func TestParseGitRemoteInvalid(t *testing.T) {
 		t.Error("expected error for invalid remote URL")
 	}
 }
+
+func TestParseGHListOutput(t *testing.T) {
+	entries, err := parseGHListOutput([]byte(`[{"name":"ASC_APP_ID","value":"123"},{"name":"BUNDLE_ID","value":"com.example.app"}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[1].Name != "BUNDLE_ID" || entries[1].Value != "com.example.app" {
+		t.Errorf("unexpected entry: %+v", entries[1])
+	}
+}
+
+func TestParseGHListOutputEmpty(t *testing.T) {
+	entries, err := parseGHListOutput([]byte("\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no entries, got %d", len(entries))
+	}
+}
+
+func TestParseGHListOutputInvalid(t *testing.T) {
+	if _, err := parseGHListOutput([]byte("not json")); err == nil {
+		t.Error("expected error for invalid gh output")
+	}
+}
+
+func TestSetGitHubSecretsUsesAPIWithToken(t *testing.T) {
+	publicKey, _, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	var put []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprintf(w, `{"key_id":"1","key":"%s"}`, base64.StdEncoding.EncodeToString(publicKey[:]))
+			return
+		}
+		mu.Lock()
+		put = append(put, strings.TrimPrefix(r.URL.Path, "/repos/acme/app/actions/secrets/"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "test-token")
+
+	results := SetGitHubSecrets("acme/app", map[string]string{"ASC_KEY_ID": "a", "ASC_ISSUER_ID": "b"}, ghapi.WithBaseURL(server.URL))
+	for name, err := range results {
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+	sort.Strings(put)
+	if strings.Join(put, ",") != "ASC_ISSUER_ID,ASC_KEY_ID" {
+		t.Errorf("unexpected secrets uploaded: %v", put)
+	}
+}
+
+func TestSetGitHubSecretsReportsPublicKeyFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message":"Resource not accessible by integration"}`)
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "test-token")
+
+	results := SetGitHubSecrets("acme/app", map[string]string{"ASC_KEY_ID": "a"}, ghapi.WithBaseURL(server.URL))
+	if err := results["ASC_KEY_ID"]; err == nil || !strings.Contains(err.Error(), "Resource not accessible") {
+		t.Fatalf("expected public key error, got %v", err)
+	}
+}
+
+func TestListGitHubSettingsUseAPIWithToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/app/actions/secrets":
+			fmt.Fprint(w, `{"total_count":2,"secrets":[{"name":"ASC_KEY_ID"},{"name":"ASC_ISSUER_ID"}]}`)
+		case "/repos/acme/app/actions/variables":
+			fmt.Fprint(w, `{"total_count":1,"variables":[{"name":"RK_SCHEME","value":"App"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "test-token")
+
+	names, err := ListGitHubSecretNames("acme/app", ghapi.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ListGitHubSecretNames: %v", err)
+	}
+	if strings.Join(names, ",") != "ASC_KEY_ID,ASC_ISSUER_ID" {
+		t.Errorf("secret names = %v", names)
+	}
+
+	vars, err := ListGitHubVariables("acme/app", ghapi.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("ListGitHubVariables: %v", err)
+	}
+	if len(vars) != 1 || vars["RK_SCHEME"] != "App" {
+		t.Errorf("variables = %v", vars)
+	}
+}
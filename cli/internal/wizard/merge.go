@@ -0,0 +1,359 @@
+package wizard
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MergeWorkflow updates the ReleaseKit-managed steps of an existing workflow
+// (those whose uses: is one of this repository's actions) from the generated
+// workflow, and leaves everything else as written.
+//
+// In a managed step, uses, id and the action inputs are taken from the
+// generated step; a step name and keys such as if: or env: are kept. Inputs
+// the user added are kept when the action declares them; undeclared inputs,
+// and the alternative of an either/or pair the generated step does not use,
+// are dropped. Only the lines of managed steps are rewritten.
+//
+// When the merged upload step downloads the IPA by artifact_name, a job it
+// needs must upload that artifact. If none does, the generated
+// actions/upload-artifact step is added after the archive step of that job,
+// and job outputs that nothing reads any longer (such as an IPA path the
+// artifact replaces) are dropped.
+//
+// missing lists the generated actions that have no step in the existing
+// workflow, and actions/upload-artifact when the artifact step could not be
+// added; those need to be added by hand.
+func MergeWorkflow(existing, generated string) (merged string, missing []string, err error) {
+	var current, desired yaml.Node
+	if err := yaml.Unmarshal([]byte(existing), &current); err != nil {
+		return "", nil, fmt.Errorf("existing workflow is not valid YAML: %w", err)
+	}
+	if err := yaml.Unmarshal([]byte(generated), &desired); err != nil {
+		return "", nil, fmt.Errorf("generated workflow is not valid YAML: %w", err)
+	}
+	if len(current.Content) == 0 || len(desired.Content) == 0 {
+		return "", nil, fmt.Errorf("no ReleaseKit steps (uses: %s*) found in the existing workflow", actionPrefix)
+	}
+
+	generatedSteps := make(map[string]*yaml.Node)
+	var generatedOrder []string
+	for _, step := range jobSteps(desired.Content[0]) {
+		if name := actionName(step); name != "" && generatedSteps[name] == nil {
+			generatedSteps[name] = step
+			generatedOrder = append(generatedOrder, name)
+		}
+	}
+
+	lines := strings.SplitAfter(existing, "\n")
+	jobs := workflowJobs(current.Content[0])
+	var edits []lineEdit
+	found := make(map[string]bool)
+	for _, job := range jobs {
+		for _, step := range job.steps {
+			name := actionName(step)
+			if name == "" {
+				continue
+			}
+			found[name] = true
+			source := generatedSteps[name]
+			if source == nil {
+				continue
+			}
+			mergeStep(step, source, actionSpecs[name])
+
+			start, end, indent, err := stepLines(lines, step)
+			if err != nil {
+				return "", nil, err
+			}
+			block, err := encodeStep(step, indent)
+			if err != nil {
+				return "", nil, err
+			}
+			edits = append(edits, lineEdit{start: start, end: end, lines: block})
+		}
+	}
+	if len(found) == 0 {
+		return "", nil, fmt.Errorf("no ReleaseKit steps (uses: %s*) found in the existing workflow", actionPrefix)
+	}
+
+	handedOff := make(map[string]bool)
+	for _, job := range jobs {
+		for _, step := range job.steps {
+			artifact := mappingValue(mappingValue(step, "with"), "artifact_name")
+			if actionName(step) != "upload" || generatedSteps["upload"] == nil || artifact == nil || handedOff[artifact.Value] {
+				continue
+			}
+			handedOff[artifact.Value] = true
+			added, ok, err := addArtifactUpload(lines, current.Content[0], desired.Content[0], jobs, job, artifact.Value)
+			if err != nil {
+				return "", nil, err
+			}
+			if !ok {
+				missing = append(missing, uploadArtifactAction)
+			}
+			edits = append(edits, added...)
+		}
+	}
+
+	// Apply from the bottom up so that earlier line numbers stay valid.
+	slices.SortStableFunc(edits, func(a, b lineEdit) int { return b.start - a.start })
+	for _, edit := range edits {
+		lines = slices.Replace(lines, edit.start, edit.end, edit.lines...)
+	}
+
+	var notFound []string
+	for _, name := range generatedOrder {
+		if !found[name] {
+			notFound = append(notFound, name)
+		}
+	}
+	return strings.Join(lines, ""), append(notFound, missing...), nil
+}
+
+// lineEdit replaces lines [start, end) of a file; start == end inserts.
+type lineEdit struct {
+	start, end int
+	lines      []string
+}
+
+// uploadArtifactAction is the action that hands the IPA from the archive job
+// to the upload job in the two-job layout.
+const uploadArtifactAction = "actions/upload-artifact"
+
+// uploadsArtifact reports whether step uploads an artifact called name.
+func uploadsArtifact(step *yaml.Node, name string) bool {
+	uses := mappingValue(step, "uses")
+	if uses == nil || !strings.HasPrefix(uses.Value, uploadArtifactAction+"@") {
+		return false
+	}
+	artifact := "artifact" // upload-artifact's default name
+	if value := mappingValue(mappingValue(step, "with"), "name"); value != nil {
+		artifact = value.Value
+	}
+	return artifact == name
+}
+
+// addArtifactUpload returns the edits that make the jobs uploadJob needs (or
+// uploadJob itself when it needs none) upload the artifact it downloads. ok
+// is false when the artifact is missing and cannot be added: no such job
+// has an archive step, or the generated workflow has no step to copy.
+func addArtifactUpload(lines []string, root, generated *yaml.Node, jobs []workflowJob, uploadJob workflowJob, artifact string) (edits []lineEdit, ok bool, err error) {
+	needs := uploadJob.needs()
+	var target *workflowJob
+	var archive *yaml.Node
+	for i, job := range jobs {
+		if !slices.Contains(needs, job.name) && (len(needs) > 0 || job.name != uploadJob.name) {
+			continue
+		}
+		for _, step := range job.steps {
+			if uploadsArtifact(step, artifact) {
+				return nil, true, nil
+			}
+			if actionName(step) == "archive" && archive == nil {
+				target, archive = &jobs[i], step
+			}
+		}
+	}
+	if archive == nil {
+		return nil, false, nil
+	}
+
+	var source *yaml.Node
+	for _, step := range jobSteps(generated) {
+		if uploadsArtifact(step, artifact) {
+			source = step
+			break
+		}
+	}
+	if source == nil {
+		return nil, false, nil
+	}
+
+	_, end, indent, err := stepLines(lines, archive)
+	if err != nil {
+		return nil, false, err
+	}
+	block, err := encodeStep(source, indent)
+	if err != nil {
+		return nil, false, err
+	}
+	edits = append(edits, lineEdit{start: end, end: end, lines: append([]string{"\n"}, block...)})
+	return append(edits, unusedOutputs(lines, root, *target)...), true, nil
+}
+
+// unusedOutputs returns the edits that drop the outputs of job that no
+// expression in the workflow reads any longer, or its whole outputs: key
+// when none is read.
+func unusedOutputs(lines []string, root *yaml.Node, job workflowJob) []lineEdit {
+	key, outputs := mappingEntry(job.node, "outputs")
+	if outputs == nil || outputs.Kind != yaml.MappingNode || outputs.Style&yaml.FlowStyle != 0 {
+		return nil
+	}
+	var unused []*yaml.Node
+	for i := 0; i+1 < len(outputs.Content); i += 2 {
+		if !readsOutput(root, outputs, job.name, outputs.Content[i].Value) {
+			unused = append(unused, outputs.Content[i])
+		}
+	}
+	if len(unused) == len(outputs.Content)/2 {
+		unused = []*yaml.Node{key}
+	}
+	var edits []lineEdit
+	for _, node := range unused {
+		start := node.Line - 1
+		edits = append(edits, lineEdit{start: start, end: blockEnd(lines, start, node.Column-1)})
+	}
+	return edits
+}
+
+// readsOutput reports whether a scalar anywhere in node, other than in the
+// outputs mapping skip, mentions the output, e.g. needs.archive.outputs.ipa-path.
+func readsOutput(node, skip *yaml.Node, job, output string) bool {
+	if node == skip {
+		return false
+	}
+	if node.Kind == yaml.ScalarNode {
+		return strings.Contains(node.Value, job+".outputs") && strings.Contains(node.Value, output)
+	}
+	for _, child := range node.Content {
+		if readsOutput(child, skip, job, output) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeStep rewrites step in place from the generated step.
+func mergeStep(step, generated *yaml.Node, spec actionSpec) {
+	for i := 0; i+1 < len(generated.Content); i += 2 {
+		key, value := generated.Content[i].Value, generated.Content[i+1]
+		switch {
+		case key == "name" && mappingValue(step, "name") != nil:
+			// Keep the user's step name.
+		case key == "with":
+			mergeWith(step, value, spec)
+		default:
+			setMappingValue(step, key, value)
+		}
+	}
+}
+
+func mergeWith(step, generated *yaml.Node, spec actionSpec) {
+	with := mappingValue(step, "with")
+	if with == nil || with.Kind != yaml.MappingNode {
+		setMappingValue(step, "with", generated)
+		return
+	}
+	for i := 0; i+1 < len(generated.Content); i += 2 {
+		setMappingValue(with, generated.Content[i].Value, generated.Content[i+1])
+	}
+	for i := 0; i+1 < len(with.Content); {
+		key := with.Content[i].Value
+		if _, declared := spec.inputs[key]; !declared || replacedAlternative(spec, generated, key) {
+			with.Content = slices.Delete(with.Content, i, i+2)
+			continue
+		}
+		i += 2
+	}
+}
+
+// replacedAlternative reports whether key belongs to an either/or group of
+// which the generated inputs set another member, e.g. workspace once the
+// generated step passes project.
+func replacedAlternative(spec actionSpec, generated *yaml.Node, key string) bool {
+	for _, group := range spec.oneOf {
+		if !slices.Contains(group, key) || mappingValue(generated, key) != nil {
+			continue
+		}
+		for _, other := range group {
+			if mappingValue(generated, other) != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setMappingValue sets key to value in a mapping node, keeping the position
+// and trailing comment of an existing entry.
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			replacement := *value
+			if replacement.LineComment == "" {
+				replacement.LineComment = node.Content[i+1].LineComment
+			}
+			node.Content[i+1] = &replacement
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// stepLines returns the range of lines [start, end) holding a step of a
+// block sequence, and the indentation of its "- ". Trailing blank lines are
+// left out so that the spacing between steps is kept.
+func stepLines(lines []string, step *yaml.Node) (start, end int, indent string, err error) {
+	start = step.Line - 1
+	if start < 0 || start >= len(lines) {
+		return 0, 0, "", fmt.Errorf("line %d: step is out of range", step.Line)
+	}
+	first := lines[start]
+	dash := strings.LastIndex(first[:min(step.Column-1, len(first))], "-")
+	if dash < 0 || strings.TrimSpace(first[:dash]) != "" {
+		return 0, 0, "", fmt.Errorf("line %d: only block-style steps (- key: value) can be merged", step.Line)
+	}
+	return start, blockEnd(lines, start, dash), first[:dash], nil
+}
+
+// blockEnd returns the end of the block starting on line start: the lines
+// after it that are blank or indented deeper than indent, without trailing
+// blank lines.
+func blockEnd(lines []string, start, indent int) int {
+	end := start + 1
+	for end < len(lines) {
+		line := strings.TrimRight(lines[end], "\r\n")
+		if strings.TrimSpace(line) != "" && len(line)-len(strings.TrimLeft(line, " ")) <= indent {
+			break
+		}
+		end++
+	}
+	for end > start+1 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return end
+}
+
+// encodeStep renders step as a block sequence item indented by indent, one
+// string per line.
+func encodeStep(step *yaml.Node, indent string) ([]string, error) {
+	// Comments above the step stay in place, outside the replaced lines.
+	item := *step
+	item.HeadComment = ""
+	if len(item.Content) > 0 {
+		firstKey := *item.Content[0]
+		firstKey.HeadComment = ""
+		item.Content = append([]*yaml.Node{&firstKey}, item.Content[1:]...)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{&item}}); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	lines := strings.SplitAfter(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = indent + line
+	}
+	lines[len(lines)-1] += "\n"
+	return lines, nil
+}
@@ -0,0 +1,259 @@
+package wizard
+
+import (
+	"strings"
+	"testing"
+)
+
+// handEditedWorkflow is a workflow generated by an older release, with a test
+// step, a renamed archive step and a few keys added by hand.
+const handEditedWorkflow = `name: Release iOS App
+
+on:
+  push:
+    tags:
+      - 'v*'
+
+jobs:
+  release:
+    runs-on: macos-15
+    steps:
+      - uses: actions/checkout@v4
+
+      - name: Run tests
+        run: xcodebuild test -scheme App
+
+      # Archive with the pinned Xcode.
+      - name: Build for the App Store
+        id: archive
+        timeout-minutes: 45
+        uses: vinceglb/releasekit-ios/actions/archive@v0
+        with:
+          workspace: App.xcworkspace
+          scheme: App # main scheme
+          archive_path: build/App.xcarchive
+          bundle-id: ${{ vars.BUNDLE_ID }}
+          asc-key-id: ${{ secrets.ASC_KEY_ID }}
+
+      - name: Upload
+        if: github.ref_type == 'tag'
+        uses: vinceglb/releasekit-ios/actions/upload@v0
+        with:
+          ipa_path: ${{ steps.archive.outputs.ipa_path }}
+          app-id: ${{ vars.ASC_APP_ID }}
+
+      - name: Notify
+        run: ./scripts/notify.sh
+`
+
+func TestMergeWorkflowUpdatesManagedSteps(t *testing.T) {
+	generated, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcodeproj", Scheme: "App", ActionRef: "v1", Layout: LayoutSingleJob})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	merged, missing, err := MergeWorkflow(handEditedWorkflow, generated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(missing) > 0 {
+		t.Errorf("expected every managed step to be found, missing %v", missing)
+	}
+
+	want := `name: Release iOS App
+
+on:
+  push:
+    tags:
+      - 'v*'
+
+jobs:
+  release:
+    runs-on: macos-15
+    steps:
+      - uses: actions/checkout@v4
+
+      - name: Run tests
+        run: xcodebuild test -scheme App
+
+      # Archive with the pinned Xcode.
+      - name: Build for the App Store
+        id: archive
+        timeout-minutes: 45
+        uses: vinceglb/releasekit-ios/actions/archive@v1
+        with:
+          scheme: App # main scheme
+          archive_path: build/App.xcarchive
+          project: App.xcodeproj
+          configuration: Release
+          bundle_id: ${{ vars.BUNDLE_ID }}
+          asc_team_id: ${{ vars.ASC_TEAM_ID }}
+          asc_key_id: ${{ secrets.ASC_KEY_ID }}
+          asc_issuer_id: ${{ secrets.ASC_ISSUER_ID }}
+          asc_private_key_b64: ${{ secrets.ASC_PRIVATE_KEY_B64 }}
+
+      - name: Upload
+        if: github.ref_type == 'tag'
+        uses: vinceglb/releasekit-ios/actions/upload@v1
+        with:
+          ipa_path: ${{ steps.archive.outputs.ipa_path }}
+          app_id: ${{ vars.ASC_APP_ID }}
+          asc_key_id: ${{ secrets.ASC_KEY_ID }}
+          asc_issuer_id: ${{ secrets.ASC_ISSUER_ID }}
+          asc_private_key_b64: ${{ secrets.ASC_PRIVATE_KEY_B64 }}
+
+      - name: Notify
+        run: ./scripts/notify.sh
+`
+	if merged != want {
+		t.Errorf("merged workflow:\n%s\ndiff:\n%s", merged, UnifiedDiff("want", "got", want, merged))
+	}
+	if issues, err := ValidateWorkflow(merged); err != nil || len(issues) > 0 {
+		t.Errorf("expected a valid merged workflow, got %v %v", issues, err)
+	}
+}
+
+// baselineWorkflow is the two-job workflow of the first releases, which
+// passed the IPA path between jobs through a job output.
+const baselineWorkflow = `name: Release iOS App
+
+on:
+  workflow_dispatch:
+  push:
+    tags:
+      - 'v*'
+
+jobs:
+  archive:
+    name: Archive
+    runs-on: macos-latest
+    outputs:
+      ipa-path: ${{ steps.archive.outputs.ipa_path }}
+
+    steps:
+      - uses: actions/checkout@v4
+
+      - name: Setup ASC
+        uses: rudrankriyam/setup-asc@v1
+
+      - name: Archive
+        id: archive
+        uses: vinceglb/releasekit-ios/actions/archive@v0
+        with:
+          workspace: App.xcworkspace
+          scheme: App
+          bundle-id: ${{ vars.BUNDLE_ID }}
+          team-id: ${{ vars.ASC_TEAM_ID }}
+          asc-key-id: ${{ secrets.ASC_KEY_ID }}
+          asc-issuer-id: ${{ secrets.ASC_ISSUER_ID }}
+          asc-private-key-b64: ${{ secrets.ASC_PRIVATE_KEY_B64 }}
+
+  upload:
+    name: Upload
+    runs-on: macos-latest
+    needs: archive
+
+    steps:
+      - uses: actions/checkout@v4
+
+      - name: Setup ASC
+        uses: rudrankriyam/setup-asc@v1
+
+      - name: Upload
+        uses: vinceglb/releasekit-ios/actions/upload@v0
+        with:
+          ipa-path: ${{ needs.archive.outputs.ipa-path }}
+          app-id: ${{ vars.ASC_APP_ID }}
+          asc-key-id: ${{ secrets.ASC_KEY_ID }}
+          asc-issuer-id: ${{ secrets.ASC_ISSUER_ID }}
+          asc-private-key-b64: ${{ secrets.ASC_PRIVATE_KEY_B64 }}
+`
+
+func TestMergeWorkflowAddsArtifactUpload(t *testing.T) {
+	generated, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcworkspace", Scheme: "App"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	merged, missing, err := MergeWorkflow(baselineWorkflow, generated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(missing) > 0 {
+		t.Errorf("expected nothing missing, got %v", missing)
+	}
+	// Beyond the managed steps, the archive job gains the artifact upload and
+	// loses its ipa-path output, which leaves exactly the generated workflow.
+	if merged != generated {
+		t.Errorf("merged workflow:\n%s\ndiff:\n%s", merged, UnifiedDiff("generated", "merged", generated, merged))
+	}
+
+	// Merging again finds the artifact upload and changes nothing.
+	again, missing, err := MergeWorkflow(merged, generated)
+	if err != nil || len(missing) > 0 || again != merged {
+		t.Errorf("expected a second merge to be a no-op, got %v %v:\n%s", missing, err, again)
+	}
+}
+
+func TestMergeWorkflowKeepsReadOutputs(t *testing.T) {
+	existing := strings.Replace(baselineWorkflow, "      - name: Upload\n", "      - run: echo ${{ needs.archive.outputs.ipa-path }}\n\n      - name: Upload\n", 1)
+	generated, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcworkspace", Scheme: "App"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	merged, _, err := MergeWorkflow(existing, generated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(merged, "    outputs:\n      ipa-path: ${{ steps.archive.outputs.ipa_path }}\n") {
+		t.Errorf("expected the output still read by a step to be kept:\n%s", merged)
+	}
+}
+
+func TestMergeWorkflowReportsMissingArtifactUpload(t *testing.T) {
+	existing := `jobs:
+  build:
+    steps:
+      - run: make ipa
+  upload:
+    needs: build
+    steps:
+      - uses: vinceglb/releasekit-ios/actions/upload@v0
+        with:
+          ipa_path: App.ipa
+`
+	generated, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcworkspace", Scheme: "App"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, missing, err := MergeWorkflow(existing, generated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(missing, ",") != "archive,actions/upload-artifact" {
+		t.Errorf("expected archive and the artifact upload to be missing, got %v", missing)
+	}
+}
+
+func TestMergeWorkflowReportsMissingSteps(t *testing.T) {
+	existing := "jobs:\n  archive:\n    steps:\n      - uses: vinceglb/releasekit-ios/actions/archive@v0\n"
+	generated, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcworkspace", Scheme: "App"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, missing, err := MergeWorkflow(existing, generated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(missing, ",") != "upload" {
+		t.Errorf("expected upload to be missing, got %v", missing)
+	}
+}
+
+func TestMergeWorkflowWithoutManagedSteps(t *testing.T) {
+	generated, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcworkspace", Scheme: "App"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := MergeWorkflow("jobs:\n  build:\n    steps:\n      - run: make\n", generated); err == nil {
+		t.Error("expected error when no ReleaseKit steps exist")
+	}
+}
@@ -1,17 +1,163 @@
 package wizard
 
+import (
+	"fmt"
+	"strings"
+)
+
+// ProjectKind says whether Inputs.Workspace is an .xcworkspace or a
+// standalone .xcodeproj; it picks the xcodebuild flag and the archive action
+// input.
+type ProjectKind string
+
+const (
+	KindWorkspace ProjectKind = "workspace"
+	KindProject   ProjectKind = "project"
+)
+
+// ProjectKindForPath infers the kind from the path's extension. Anything that
+// is not an .xcodeproj is treated as a workspace.
+func ProjectKindForPath(path string) ProjectKind {
+	if strings.HasSuffix(strings.TrimRight(path, "/"), ".xcodeproj") {
+		return KindProject
+	}
+	return KindWorkspace
+}
+
+// xcodebuildFlag returns the xcodebuild option that takes a path of this
+// kind ("-workspace" or "-project").
+func (k ProjectKind) xcodebuildFlag() string {
+	if k == KindProject {
+		return "-project"
+	}
+	return "-workspace"
+}
+
+// kindTitle is the capitalized display name of a kind.
+func kindTitle(k ProjectKind) string {
+	if k == KindProject {
+		return "Project"
+	}
+	return "Workspace"
+}
+
 type Inputs struct {
-	Workspace        string
-	Scheme           string
-	BundleID         string
-	TeamID           string
-	AppID            string
-	AppName          string // display only
-	ASCKeyID         string
-	ASCIssuerID      string
-	ASCPrivateKeyB64 string
-	GitHubRepo       string // "owner/repo"
-	SecretsWereSet   bool
-	WorkflowWasWritten bool
-	WorkflowPath     string
+	Workspace             string      // .xcworkspace, or .xcodeproj when ProjectKind is KindProject
+	ProjectKind           ProjectKind // set from the Workspace path; empty means KindWorkspace
+	Scheme                string
+	BundleID              string
+	TeamID                string
+	AppID                 string
+	AppName               string // display only
+	ASCKeyID              string
+	ASCIssuerID           string
+	ASCPrivateKeyB64      string
+	GitHubRepo            string // "owner/repo"
+	SecretsWereSet        bool
+	VariablesWereSet      bool
+	WorkflowWasWritten    bool
+	WorkflowPath          string
+	WorkflowTemplate      string           // user-supplied workflow template; blank uses the built-in one
+	Extensions            []EmbeddedTarget // extensions and watch apps embedded in the app
+	Configuration         string           // Xcode build configuration; defaults to Release
+	RunnerLabel           string           // comma-separated runs-on labels; defaults to macos-latest
+	XcodeVersion          string           // selected on the runner before archiving, if set
+	ActionRef             string           // ref of the releasekit-ios actions; defaults to v0
+	XcodebuildExtraArgs   string           // passed to the archive action
+	ConfigPath            string           // set once the project config has been written
+	WorkflowLayout        string           // LayoutTwoJobs (default) or LayoutSingleJob
+	ArtifactName          string           // artifact carrying the IPA between jobs
+	ArtifactRetentionDays int              // days GitHub keeps the IPA artifact
+	ExportOptionsPath     string           // ExportOptions.plist passed to the archive action, if any
+	ExportOptions         *ExportOptionsConfig
+}
+
+// Options holds values supplied up front through flags or RELEASEKIT_* env
+// vars. In non-interactive mode they replace every wizard prompt.
+type Options struct {
+	NonInteractive bool
+	Workspace      string
+	Project        string // standalone .xcodeproj; mutually exclusive with Workspace
+	Scheme         string
+	BundleID       string
+	TeamID         string
+	AppID          string
+	ASCKeyID       string
+	ASCIssuerID    string
+	P8Path         string
+	P8B64          string
+	GitHubRepo     string // "owner/repo"
+	SetSecrets     bool
+	SetVariables   bool
+	WriteWorkflow  bool
+	Force          bool
+	RevealSecrets  bool // print secret values in full in the summary
+	NoSaveConfig   bool // leave .releasekit.yml untouched (non-interactive mode)
+
+	// Workflow generation; blank values keep the project config's.
+	Template            string // path of a workflow template to render instead of the built-in one
+	RunnerLabel         string
+	XcodeVersion        string
+	ActionRef           string
+	Configuration       string
+	XcodebuildExtraArgs string
+
+	WorkflowLayout        string // LayoutTwoJobs or LayoutSingleJob
+	ArtifactName          string
+	ArtifactRetentionDays int // 0 keeps the project config's
+}
+
+// container returns the --workspace or --project path, whichever was given.
+func (o Options) container() (string, error) {
+	workspace, project := strings.TrimSpace(o.Workspace), strings.TrimSpace(o.Project)
+	if workspace != "" && project != "" {
+		return "", fmt.Errorf("use either --workspace or --project, not both")
+	}
+	return workspace + project, nil
+}
+
+// applyWorkflowFlags overrides the workflow settings in inputs with the ones
+// given as flags.
+func (o Options) applyWorkflowFlags(inputs *Inputs) {
+	for _, f := range []struct {
+		target *string
+		value  string
+	}{
+		{&inputs.WorkflowTemplate, o.Template},
+		{&inputs.RunnerLabel, o.RunnerLabel},
+		{&inputs.XcodeVersion, o.XcodeVersion},
+		{&inputs.ActionRef, o.ActionRef},
+		{&inputs.Configuration, o.Configuration},
+		{&inputs.XcodebuildExtraArgs, o.XcodebuildExtraArgs},
+		{&inputs.WorkflowLayout, o.WorkflowLayout},
+		{&inputs.ArtifactName, o.ArtifactName},
+	} {
+		if value := strings.TrimSpace(f.value); value != "" {
+			*f.target = value
+		}
+	}
+	if o.ArtifactRetentionDays != 0 {
+		inputs.ArtifactRetentionDays = o.ArtifactRetentionDays
+	}
+}
+
+// EnvVarForFlag returns the environment variable that backs a wizard flag,
+// e.g. "asc-key-id" -> "RELEASEKIT_ASC_KEY_ID".
+func EnvVarForFlag(flag string) string {
+	return "RELEASEKIT_" + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// Kind returns the recorded project kind, inferring it from the path when it
+// was never set.
+func (i Inputs) Kind() ProjectKind {
+	if i.ProjectKind != "" {
+		return i.ProjectKind
+	}
+	return ProjectKindForPath(i.Workspace)
+}
+
+// setContainer records path and its kind.
+func (i *Inputs) setContainer(path string) {
+	i.Workspace = path
+	i.ProjectKind = ProjectKindForPath(path)
 }
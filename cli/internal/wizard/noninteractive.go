@@ -0,0 +1,263 @@
+package wizard
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/term"
+)
+
+// missingInput describes a value the non-interactive wizard could not resolve.
+type missingInput struct {
+	label string
+	flags []string
+	note  string
+}
+
+func (m missingInput) String() string {
+	sources := make([]string, 0, len(m.flags)*2)
+	for _, flag := range m.flags {
+		sources = append(sources, "--"+flag, EnvVarForFlag(flag))
+	}
+	line := fmt.Sprintf("%s (%s)", m.label, strings.Join(sources, " / "))
+	if m.note != "" {
+		line += ": " + m.note
+	}
+	return line
+}
+
+// runNonInteractive runs the wizard from Options alone. It performs the same
+// detection and validation as the interactive flow but never prompts.
+func runNonInteractive(out io.Writer, theme term.Theme, opts Options) error {
+	_, _, ghAuthed := checkPrerequisites(out, theme)
+	canSetGitHub := canSetGitHubSettings(ghAuthed)
+
+	inputs, missing, err := resolveNonInteractiveInputs(opts)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return missingInputsError(missing)
+	}
+	if err := normalizeKeyInput(out, theme, &inputs.ASCPrivateKeyB64); err != nil {
+		return err
+	}
+
+	apps, err := ListASCApps(inputs.ASCKeyID, inputs.ASCIssuerID, inputs.ASCPrivateKeyB64)
+	if err != nil {
+		fmt.Fprintf(out, "%s Failed to validate credentials: %v\n\n", theme.Error("✗"), err)
+		return err
+	}
+	fmt.Fprintf(out, "%s App Store Connect credentials validated (%d apps found)\n\n",
+		theme.Success("✓"), len(apps))
+
+	if err := resolveASCApp(&inputs, apps); err != nil {
+		return err
+	}
+
+	if err := validateInputs(inputs); err != nil {
+		return err
+	}
+	if mismatch := bundleIDMismatch(inputs); mismatch != "" {
+		fmt.Fprintf(out, "%s Bundle ID mismatch: %s\n\n", theme.Failure("!"), mismatch)
+	}
+	reportExtensions(out, theme, inputs, detectExtensions(&inputs))
+	if shared := checkSchemeShared(inputs.Workspace, inputs.Scheme); shared.Status == CheckFail {
+		fmt.Fprintf(out, "%s %s\n  %s\n\n", theme.Failure("!"), shared.Detail, theme.Muted("→ "+shared.Hint))
+	}
+
+	repoSlug := inputs.GitHubRepo
+	if repoSlug == "" && canSetGitHub {
+		if owner, repo, detectErr := DetectGitRepo(); detectErr == nil {
+			repoSlug = owner + "/" + repo
+		}
+	}
+	inputs.GitHubRepo = repoSlug
+
+	if opts.SetSecrets || opts.SetVariables {
+		flag := "--set-secrets"
+		if !opts.SetSecrets {
+			flag = "--set-variables"
+		}
+		if !canSetGitHub {
+			return fmt.Errorf("%s requires GITHUB_TOKEN, GH_TOKEN or an authenticated gh CLI (run: gh auth login)", flag)
+		}
+		if repoSlug == "" {
+			return fmt.Errorf("%s requires a GitHub repository (--repo or %s)", flag, EnvVarForFlag("repo"))
+		}
+	}
+	if opts.SetSecrets {
+		fmt.Fprintln(out, theme.Section("GitHub Secrets"))
+		if !applyGitHubSecrets(out, theme, &inputs, repoSlug) {
+			return fmt.Errorf("failed to set one or more GitHub secrets on %s", repoSlug)
+		}
+	}
+	if opts.SetVariables {
+		fmt.Fprintln(out, theme.Section("GitHub Variables"))
+		if !applyGitHubVariables(out, theme, &inputs, repoSlug) {
+			return fmt.Errorf("failed to set one or more GitHub variables on %s", repoSlug)
+		}
+	}
+
+	if opts.WriteWorkflow {
+		if fileExists(inputs.WorkflowPath) && !opts.Force {
+			fmt.Fprintf(out, "  %s %s already exists (use --force to overwrite)\n\n",
+				theme.Muted("○"), inputs.WorkflowPath)
+		} else if err := writeWorkflowFile(out, theme, &inputs); err != nil {
+			return err
+		}
+	}
+
+	if !opts.NoSaveConfig {
+		if err := saveProjectConfig(out, theme, &inputs, false); err != nil {
+			return err
+		}
+	}
+
+	printSummary(out, theme, inputs, opts.RevealSecrets)
+	return nil
+}
+
+// resolveNonInteractiveInputs builds Inputs from opts, filling gaps from the
+// project config and then from local project detection. It returns every
+// value that is still missing.
+func resolveNonInteractiveInputs(opts Options) (Inputs, []missingInput, error) {
+	var saved Config
+	if fileExists(DefaultConfigPath) {
+		cfg, err := LoadConfig(DefaultConfigPath)
+		if err != nil {
+			return Inputs{}, nil, err
+		}
+		saved = cfg
+	}
+	inputs := saved.Inputs()
+	override := func(target *string, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			*target = value
+		}
+	}
+	container, err := opts.container()
+	if err != nil {
+		return Inputs{}, nil, err
+	}
+	if container != "" {
+		inputs.setContainer(container)
+	}
+	override(&inputs.Scheme, opts.Scheme)
+	override(&inputs.BundleID, opts.BundleID)
+	override(&inputs.TeamID, opts.TeamID)
+	override(&inputs.AppID, opts.AppID)
+	override(&inputs.GitHubRepo, opts.GitHubRepo)
+	opts.applyWorkflowFlags(&inputs)
+	inputs.ASCKeyID = strings.TrimSpace(opts.ASCKeyID)
+	inputs.ASCIssuerID = strings.TrimSpace(opts.ASCIssuerID)
+
+	privKeyB64, err := resolvePrivateKey(opts.P8Path, opts.P8B64)
+	if err != nil {
+		return Inputs{}, nil, err
+	}
+	inputs.ASCPrivateKeyB64 = privKeyB64
+
+	var workspaceNote string
+	if inputs.Workspace == "" {
+		candidates := detectAllWorkspaceCandidates(".")
+		switch len(candidates) {
+		case 0:
+			workspaceNote = "no .xcworkspace or .xcodeproj found"
+		case 1:
+			inputs.setContainer(candidates[0])
+		default:
+			workspaceNote = "multiple found: " + strings.Join(candidates, ", ")
+		}
+	}
+	if inputs.Workspace != "" {
+		if inputs.Scheme == "" {
+			if schemes, _ := DetectSchemes(inputs.Workspace); len(schemes) == 1 {
+				inputs.Scheme = schemes[0]
+			}
+		}
+		if inputs.TeamID == "" {
+			inputs.TeamID, _ = DetectTeamID(inputs.Workspace)
+		}
+	}
+
+	return inputs, missingInputs(inputs, workspaceNote), nil
+}
+
+// resolvePrivateKey returns the base64 .p8 content from either a file path or
+// an inline base64 value. Both blank yields "", nil.
+func resolvePrivateKey(p8Path, p8B64 string) (string, error) {
+	p8Path = strings.TrimSpace(p8Path)
+	p8B64 = strings.TrimSpace(p8B64)
+	switch {
+	case p8Path != "" && p8B64 != "":
+		return "", fmt.Errorf("use either --p8-path or --p8-b64, not both")
+	case p8Path != "":
+		if _, statErr := os.Stat(p8Path); statErr != nil {
+			return "", fmt.Errorf("p8 file not found: %s", p8Path)
+		}
+		encoded, encErr := encodeFileBase64(p8Path)
+		if encErr != nil {
+			return "", fmt.Errorf("could not read .p8 file: %w", encErr)
+		}
+		return encoded, nil
+	default:
+		return p8B64, nil
+	}
+}
+
+// missingInputs lists required values that are still blank. App ID and bundle
+// ID are resolved from each other through App Store Connect, so only one of
+// them is required up front.
+func missingInputs(inputs Inputs, workspaceNote string) []missingInput {
+	var missing []missingInput
+	add := func(value, label, note string, flags ...string) {
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, missingInput{label: label, flags: flags, note: note})
+		}
+	}
+
+	add(inputs.Workspace, "Xcode workspace or project path", workspaceNote, "workspace", "project")
+	add(inputs.Scheme, "Xcode scheme", "", "scheme")
+	add(inputs.TeamID, "Apple Team ID", "", "team-id")
+	add(inputs.AppID+inputs.BundleID, "App Store Connect app ID or bundle ID", "", "app-id", "bundle-id")
+	add(inputs.ASCKeyID, "ASC Key ID", "", "asc-key-id")
+	add(inputs.ASCIssuerID, "ASC Issuer ID", "", "asc-issuer-id")
+	add(inputs.ASCPrivateKeyB64, "ASC private key", "", "p8-path", "p8-b64")
+	return missing
+}
+
+func missingInputsError(missing []missingInput) error {
+	lines := make([]string, len(missing))
+	for i, m := range missing {
+		lines[i] = "  - " + m.String()
+	}
+	return fmt.Errorf("missing required values for --non-interactive:\n%s", strings.Join(lines, "\n"))
+}
+
+// resolveASCApp matches inputs against the App Store Connect app list, filling
+// the app ID from the bundle ID (or vice versa) and the display name.
+func resolveASCApp(inputs *Inputs, apps []ASCApp) error {
+	for _, app := range apps {
+		if inputs.AppID != "" && app.ID != inputs.AppID {
+			continue
+		}
+		if inputs.AppID == "" && app.Attributes.BundleID != inputs.BundleID {
+			continue
+		}
+		if inputs.BundleID != "" && app.Attributes.BundleID != inputs.BundleID {
+			return fmt.Errorf("App Store Connect app %s has bundle ID %s, not %s", app.ID, app.Attributes.BundleID, inputs.BundleID)
+		}
+		inputs.AppID = app.ID
+		inputs.AppName = app.Attributes.Name
+		inputs.BundleID = app.Attributes.BundleID
+		return nil
+	}
+
+	if inputs.AppID == "" {
+		return fmt.Errorf("no App Store Connect app found with bundle ID %s", inputs.BundleID)
+	}
+	return fmt.Errorf("no App Store Connect app found with ID %s (check the ID and that the API key has access to the app)", inputs.AppID)
+}
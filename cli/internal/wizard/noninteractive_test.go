@@ -0,0 +1,165 @@
+package wizard
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnvVarForFlag(t *testing.T) {
+	cases := map[string]string{
+		"workspace":  "RELEASEKIT_WORKSPACE",
+		"asc-key-id": "RELEASEKIT_ASC_KEY_ID",
+		"p8-b64":     "RELEASEKIT_P8_B64",
+	}
+	for flag, want := range cases {
+		if got := EnvVarForFlag(flag); got != want {
+			t.Errorf("EnvVarForFlag(%q) = %q, want %q", flag, got, want)
+		}
+	}
+}
+
+func TestMissingInputsListsEveryBlankValue(t *testing.T) {
+	missing := missingInputs(Inputs{Scheme: "App"}, "no .xcworkspace or .xcodeproj found")
+	if len(missing) != 6 {
+		t.Fatalf("expected 6 missing values, got %d: %v", len(missing), missing)
+	}
+
+	err := missingInputsError(missing).Error()
+	for _, want := range []string{
+		"Xcode workspace or project path (--workspace / RELEASEKIT_WORKSPACE / --project / RELEASEKIT_PROJECT): no .xcworkspace or .xcodeproj found",
+		"--app-id / RELEASEKIT_APP_ID / --bundle-id / RELEASEKIT_BUNDLE_ID",
+		"--p8-path / RELEASEKIT_P8_PATH / --p8-b64 / RELEASEKIT_P8_B64",
+	} {
+		if !strings.Contains(err, want) {
+			t.Errorf("expected error to contain %q, got:\n%s", want, err)
+		}
+	}
+	if strings.Contains(err, "Xcode scheme") {
+		t.Errorf("scheme was provided and should not be listed:\n%s", err)
+	}
+}
+
+func TestMissingInputsAcceptsBundleIDWithoutAppID(t *testing.T) {
+	inputs := Inputs{
+		Workspace:        "App.xcworkspace",
+		Scheme:           "App",
+		TeamID:           "ABCDE12345",
+		BundleID:         "com.example.app",
+		ASCKeyID:         "KEYID12345",
+		ASCIssuerID:      "00000000-0000-0000-0000-000000000000",
+		ASCPrivateKeyB64: "cHJpdmF0ZS1rZXk=",
+	}
+	if missing := missingInputs(inputs, ""); len(missing) != 0 {
+		t.Fatalf("expected no missing values, got %v", missing)
+	}
+}
+
+func TestResolveNonInteractiveInputsRejectsBothKeySources(t *testing.T) {
+	_, _, err := resolveNonInteractiveInputs(Options{P8Path: "/tmp/AuthKey.p8", P8B64: "cHJpdmF0ZS1rZXk="})
+	if err == nil {
+		t.Fatal("expected error when both --p8-path and --p8-b64 are set")
+	}
+}
+
+func TestResolveASCAppFromBundleID(t *testing.T) {
+	apps := testASCApps()
+	inputs := Inputs{BundleID: "com.example.two"}
+	if err := resolveASCApp(&inputs, apps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inputs.AppID != "2" || inputs.AppName != "App Two" {
+		t.Errorf("unexpected resolution: %+v", inputs)
+	}
+}
+
+func TestResolveASCAppFromAppID(t *testing.T) {
+	apps := testASCApps()
+	inputs := Inputs{AppID: "1"}
+	if err := resolveASCApp(&inputs, apps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inputs.BundleID != "com.example.one" {
+		t.Errorf("expected bundle ID com.example.one, got %q", inputs.BundleID)
+	}
+}
+
+func TestResolveASCAppUnknownBundleID(t *testing.T) {
+	inputs := Inputs{BundleID: "com.example.missing"}
+	if err := resolveASCApp(&inputs, testASCApps()); err == nil {
+		t.Fatal("expected error for unknown bundle ID")
+	}
+}
+
+func TestResolveASCAppBundleIDMismatch(t *testing.T) {
+	inputs := Inputs{AppID: "1", BundleID: "com.example.two"}
+	err := resolveASCApp(&inputs, testASCApps())
+	if err == nil || !strings.Contains(err.Error(), "com.example.one") {
+		t.Fatalf("expected bundle ID mismatch error, got %v", err)
+	}
+}
+
+func TestResolveASCAppUnknownAppIDWithBundleID(t *testing.T) {
+	inputs := Inputs{AppID: "999", BundleID: "com.example.one"}
+	err := resolveASCApp(&inputs, testASCApps())
+	if err == nil || !strings.Contains(err.Error(), "no App Store Connect app found with ID 999") {
+		t.Fatalf("expected unknown app ID error, got %v", err)
+	}
+}
+
+func testASCApps() []ASCApp {
+	apps := make([]ASCApp, 2)
+	apps[0].ID = "1"
+	apps[0].Attributes.Name = "App One"
+	apps[0].Attributes.BundleID = "com.example.one"
+	apps[1].ID = "2"
+	apps[1].Attributes.Name = "App Two"
+	apps[1].Attributes.BundleID = "com.example.two"
+	return apps
+}
+
+func TestPreselectAppID(t *testing.T) {
+	apps := testASCApps()
+	detected := []string{"com.unknown", apps[1].Attributes.BundleID}
+	if got := preselectAppID(apps, detected, apps[0].ID); got != apps[0].ID {
+		t.Errorf("expected saved app ID to win, got %q", got)
+	}
+	if got := preselectAppID(apps, detected, ""); got != apps[1].ID {
+		t.Errorf("expected detected bundle ID without a saved app, got %q", got)
+	}
+	if got := preselectAppID(apps, detected, "gone"); got != apps[1].ID {
+		t.Errorf("expected detected bundle ID when the saved app is not listed, got %q", got)
+	}
+	if got := preselectAppID(apps, []string{"com.unknown"}, ""); got != "" {
+		t.Errorf("expected no preselection, got %q", got)
+	}
+}
+
+func TestApplyWorkflowFlagsOverridesSavedSettings(t *testing.T) {
+	inputs := Inputs{RunnerLabel: "macos-15", ActionRef: "v0", Configuration: "Staging"}
+	Options{RunnerLabel: " self-hosted, macOS ", XcodeVersion: "16.2"}.applyWorkflowFlags(&inputs)
+	if inputs.RunnerLabel != "self-hosted, macOS" || inputs.XcodeVersion != "16.2" {
+		t.Errorf("expected flags to override, got %+v", inputs)
+	}
+	if inputs.ActionRef != "v0" || inputs.Configuration != "Staging" {
+		t.Errorf("expected unset flags to keep saved values, got %+v", inputs)
+	}
+}
+
+func TestApplyWorkflowFlagsSetsLayoutAndArtifact(t *testing.T) {
+	inputs := Inputs{WorkflowLayout: LayoutTwoJobs, ArtifactName: "saved", ArtifactRetentionDays: 3}
+	Options{WorkflowLayout: LayoutSingleJob, ArtifactRetentionDays: 30}.applyWorkflowFlags(&inputs)
+	if inputs.WorkflowLayout != LayoutSingleJob || inputs.ArtifactRetentionDays != 30 {
+		t.Errorf("expected flags to override, got %+v", inputs)
+	}
+	if inputs.ArtifactName != "saved" {
+		t.Errorf("expected the unset artifact name to keep the saved value, got %q", inputs.ArtifactName)
+	}
+
+	for _, opts := range []Options{{WorkflowLayout: "three-jobs"}, {ArtifactRetentionDays: 91}} {
+		inputs := Inputs{}
+		opts.applyWorkflowFlags(&inputs)
+		if err := validateWorkflowOptions(inputs.WorkflowOptions()); err == nil {
+			t.Errorf("expected %+v to be rejected", opts)
+		}
+	}
+}
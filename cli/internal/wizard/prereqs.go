@@ -5,20 +5,19 @@ import (
 	"io"
 	"os/exec"
 
+	"github.com/vinceglb/releasekit-ios/cli/internal/ghapi"
 	"github.com/vinceglb/releasekit-ios/cli/internal/term"
 )
 
-// checkPrerequisites checks for required and optional tools.
-// Returns an error (and prints a hard-exit message) only when `asc` is missing.
-// xcodeOK, ghOK, ghAuthed are non-blocking signals for graceful fallback.
-func checkPrerequisites(out io.Writer, theme term.Theme) (xcodeOK, ghOK, ghAuthed bool, err error) {
+// checkPrerequisites checks for optional tools. App Store Connect is reached
+// through its REST API, so nothing here is required; xcodeOK, ghOK and
+// ghAuthed are signals for graceful fallback.
+func checkPrerequisites(out io.Writer, theme term.Theme) (xcodeOK, ghOK, ghAuthed bool) {
 	fmt.Fprintln(out, theme.Section("Prerequisites"))
 
-	ascOK := commandExists("asc")
 	xcodeOK = commandExists("xcodebuild")
 	ghOK = commandExists("gh")
 
-	printCheck(out, theme, "asc", ascOK)
 	printCheck(out, theme, "xcodebuild", xcodeOK)
 	printCheck(out, theme, "gh", ghOK)
 
@@ -30,17 +29,13 @@ func checkPrerequisites(out io.Writer, theme term.Theme) (xcodeOK, ghOK, ghAuthe
 			fmt.Fprintf(out, "  %s gh not authenticated (run: gh auth login)\n", theme.Muted("○"))
 		}
 	}
+	if _, source := ghapi.ResolveToken(); source != "" {
+		fmt.Fprintf(out, "  %s GitHub token (%s)\n", theme.Success("✓"), source)
+	}
 
 	fmt.Fprintln(out)
 
-	if !ascOK {
-		fmt.Fprintln(out, theme.Error("asc CLI not found. Install it with:"))
-		fmt.Fprintln(out, theme.Value("  brew install rudrankriyam/tap/asc"))
-		fmt.Fprintln(out)
-		return false, false, false, fmt.Errorf("asc CLI is required")
-	}
-
-	return xcodeOK, ghOK, ghAuthed, nil
+	return xcodeOK, ghOK, ghAuthed
 }
 
 func printCheck(out io.Writer, theme term.Theme, name string, ok bool) {
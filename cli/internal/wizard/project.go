@@ -0,0 +1,85 @@
+package wizard
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vinceglb/releasekit-ios/cli/internal/pbxproj"
+)
+
+// TargetSettings are the release-relevant build settings of one target in one
+// build configuration, resolved from a project.pbxproj.
+type TargetSettings struct {
+	ProjectPath      string // path to the .xcodeproj
+	Target           string
+	ProductType      string
+	Configuration    string
+	TeamID           string
+	BundleID         string
+	CodeSignStyle    string
+	MarketingVersion string
+}
+
+// DetectTargetSettings parses the projects referenced by the workspace and
+// returns the settings of each target in each build configuration.
+func DetectTargetSettings(workspacePath string) []TargetSettings {
+	settings, _ := detectTargetSettings(workspaceProjectFiles(workspacePath))
+	return settings
+}
+
+// detectTargetSettings parses files and returns their target settings along
+// with the files that could not be parsed or define no targets.
+func detectTargetSettings(files []string) (settings []TargetSettings, unparsed []string) {
+	for _, path := range files {
+		project, err := pbxproj.ParseFile(path)
+		if err != nil || len(project.Targets) == 0 {
+			unparsed = append(unparsed, path)
+			continue
+		}
+		projectPath := filepath.Dir(path)
+		for _, target := range project.Targets {
+			for _, configuration := range project.ConfigurationNames() {
+				if _, ok := target.Configuration(configuration); !ok {
+					continue
+				}
+				settings = append(settings, TargetSettings{
+					ProjectPath:      projectPath,
+					Target:           target.Name,
+					ProductType:      target.ProductType,
+					Configuration:    configuration,
+					TeamID:           project.Setting(target, configuration, "DEVELOPMENT_TEAM"),
+					BundleID:         project.Setting(target, configuration, "PRODUCT_BUNDLE_IDENTIFIER"),
+					CodeSignStyle:    project.Setting(target, configuration, "CODE_SIGN_STYLE"),
+					MarketingVersion: project.Setting(target, configuration, "MARKETING_VERSION"),
+				})
+			}
+		}
+	}
+	return settings, unparsed
+}
+
+// findPbxprojFiles returns the .xcodeproj/project.pbxproj files under root,
+// skipping dependency and build directories.
+func findPbxprojFiles(root string) []string {
+	var files []string
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case ".git", "Pods", "Carthage", ".build", "DerivedData", "node_modules", ".swiftpm":
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == "project.pbxproj" && strings.HasSuffix(filepath.Dir(path), ".xcodeproj") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,61 @@
+package wizard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFixtureProject copies the pbxproj fixture to root/App.xcodeproj.
+func writeFixtureProject(t *testing.T, root string) {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("..", "pbxproj", "testdata", "App.pbxproj"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectDir := filepath.Join(root, "App.xcodeproj")
+	if err := os.MkdirAll(projectDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, "project.pbxproj"), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDetectTeamIDPrefersAppReleaseConfiguration(t *testing.T) {
+	root := t.TempDir()
+	writeFixtureProject(t, root)
+
+	// ABCDE12345 is more frequent, but the app's Release configuration overrides it.
+	teamID, err := DetectTeamID(filepath.Join(root, "App.xcworkspace"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if teamID != "ZYXWV98765" {
+		t.Errorf("expected ZYXWV98765, got %q", teamID)
+	}
+}
+
+func TestDetectTargetSettings(t *testing.T) {
+	root := t.TempDir()
+	writeFixtureProject(t, root)
+
+	settings := DetectTargetSettings(filepath.Join(root, "App.xcworkspace"))
+	if len(settings) != 4 {
+		t.Fatalf("expected 2 targets x 2 configurations, got %d: %+v", len(settings), settings)
+	}
+	byKey := make(map[string]TargetSettings)
+	for _, s := range settings {
+		byKey[s.Target+"/"+s.Configuration] = s
+	}
+	app := byKey["App/Release"]
+	if app.BundleID != "com.example.app" || app.CodeSignStyle != "Manual" || app.MarketingVersion != "1.2.0" {
+		t.Errorf("unexpected App/Release settings: %+v", app)
+	}
+	if widget := byKey["Widget/Release"]; widget.BundleID != "com.example.app.Widget" || widget.TeamID != "ABCDE12345" {
+		t.Errorf("unexpected Widget/Release settings: %+v", widget)
+	}
+	if ids := DetectBundleIDs(root); len(ids) != 3 {
+		t.Errorf("expected 3 distinct bundle IDs, got %v", ids)
+	}
+}
@@ -4,26 +4,33 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/huh/spinner"
 	"github.com/vinceglb/releasekit-ios/cli/internal/term"
 )
 
-func Run(out io.Writer) error {
+func Run(out io.Writer, opts Options) error {
 	theme := term.NewTheme()
 
 	fmt.Fprintln(out, theme.Title("ReleaseKit-iOS Wizard"))
 	fmt.Fprintln(out, theme.Muted("Guided setup for distributing iOS apps to the App Store."))
 	fmt.Fprintln(out)
 
-	// Phase 0: Prerequisites.
-	xcodeOK, ghOK, ghAuthed, err := checkPrerequisites(out, theme)
-	if err != nil {
-		return err
+	if opts.NonInteractive {
+		return runNonInteractive(out, theme, opts)
 	}
+
+	saved := loadSavedConfig(out, theme)
+
+	// Phase 0: Prerequisites.
+	xcodeOK, ghOK, ghAuthed := checkPrerequisites(out, theme)
 	_ = xcodeOK
 	_ = ghOK
+	canSetGitHub := canSetGitHubSettings(ghAuthed)
 
 	// Phase 1: ASC credentials.
 	fmt.Fprintln(out, theme.Section("Phase 1 — App Store Connect Credentials"))
@@ -34,6 +41,10 @@ func Run(out io.Writer) error {
 		return err
 	}
 
+	if err := normalizeKeyInput(out, theme, &privKeyB64); err != nil {
+		return err
+	}
+
 	// Validate credentials with spinner (also fetches app list).
 	var apps []ASCApp
 	var ascErr error
@@ -58,7 +69,7 @@ func Run(out io.Writer) error {
 	fmt.Fprintln(out, theme.Section("Phase 2 — App Selection"))
 	fmt.Fprintln(out)
 
-	appName, appID, bundleID, err := collectPhase2App(apps)
+	appName, appID, bundleID, err := collectPhase2App(apps, DetectBundleIDs("."), saved)
 	if err != nil {
 		return err
 	}
@@ -69,22 +80,30 @@ func Run(out io.Writer) error {
 
 	var candidates []string
 	var schemes []string
+	var unshared map[string]bool
 	var detectedTeamID string
 
 	if spinErr := spinner.New().
 		Title("Detecting Xcode project…").
 		Action(func() {
 			candidates = detectAllWorkspaceCandidates(".")
+			detectFor := ""
 			if len(candidates) == 1 {
-				schemes, _ = DetectSchemes(candidates[0])
-				detectedTeamID, _ = DetectTeamID(candidates[0])
+				detectFor = candidates[0]
+			} else if savedPath := saved.Inputs().Workspace; slices.Contains(candidates, savedPath) {
+				detectFor = savedPath
+			}
+			if detectFor != "" {
+				schemes, _ = DetectSchemes(detectFor)
+				unshared = unsharedSchemes(FindSchemeFiles(detectFor))
+				detectedTeamID, _ = DetectTeamID(detectFor)
 			}
 		}).
 		Run(); spinErr != nil && !errors.Is(spinErr, huh.ErrUserAborted) {
 		// Non-fatal: proceed with empty candidates.
 	}
 
-	workspace, scheme, teamID, bundleID, err := collectPhase3Xcode(candidates, schemes, detectedTeamID, bundleID)
+	workspace, scheme, teamID, bundleID, err := collectPhase3Xcode(candidates, schemes, unshared, detectedTeamID, bundleID, saved)
 	if err != nil {
 		return err
 	}
@@ -94,23 +113,62 @@ func Run(out io.Writer) error {
 		AppID:            appID,
 		BundleID:         bundleID,
 		Workspace:        workspace,
+		ProjectKind:      ProjectKindForPath(workspace),
 		Scheme:           scheme,
 		TeamID:           teamID,
 		ASCKeyID:         keyID,
 		ASCIssuerID:      issuerID,
 		ASCPrivateKeyB64: privKeyB64,
+		Configuration:    saved.Configuration,
+		RunnerLabel:      saved.RunnerLabel,
+		WorkflowPath:     saved.WorkflowPath,
+
+		WorkflowTemplate:    saved.WorkflowTemplate,
+		XcodeVersion:        saved.XcodeVersion,
+		ActionRef:           saved.ActionRef,
+		XcodebuildExtraArgs: saved.XcodebuildExtraArgs,
+
+		WorkflowLayout:        saved.WorkflowLayout,
+		ArtifactName:          saved.ArtifactName,
+		ArtifactRetentionDays: saved.ArtifactRetentionDays,
+		ExportOptionsPath:     saved.ExportOptionsPath,
+		ExportOptions:         saved.ExportOptions,
 	}
 
+	opts.applyWorkflowFlags(&inputs)
+
 	if err := validateInputs(inputs); err != nil {
 		return err
 	}
+	if err := offerShareScheme(out, theme, inputs); err != nil {
+		return err
+	}
+
+	var mismatch string
+	var extensionsErr error
+	if spinErr := spinner.New().
+		Title("Checking the bundle IDs against the Xcode project…").
+		Action(func() {
+			mismatch = bundleIDMismatch(inputs)
+			extensionsErr = detectExtensions(&inputs)
+		}).
+		Run(); spinErr != nil && !errors.Is(spinErr, huh.ErrUserAborted) {
+		// Non-fatal: skip the cross-check.
+	}
+	if mismatch != "" {
+		fmt.Fprintf(out, "%s Bundle ID mismatch: %s\n\n", theme.Failure("!"), mismatch)
+		if err := confirmBundleIDMismatch(); err != nil {
+			return err
+		}
+	}
+	reportExtensions(out, theme, inputs, extensionsErr)
 
 	// Phase 4: GitHub setup.
 	fmt.Fprintln(out, theme.Section("Phase 4 — GitHub Setup"))
 	fmt.Fprintln(out)
 
 	var ghOwner, ghRepo string
-	if ghAuthed {
+	if canSetGitHub {
 		if spinErr := spinner.New().
 			Title("Detecting git repository…").
 			Action(func() {
@@ -121,11 +179,179 @@ func Run(out io.Writer) error {
 		}
 	}
 
-	if err := collectPhase4GitHub(out, theme, &inputs, ghAuthed, ghOwner, ghRepo); err != nil {
+	if err := collectPhase4GitHub(out, theme, &inputs, canSetGitHub, ghOwner, ghRepo); err != nil {
+		return err
+	}
+
+	if err := saveProjectConfig(out, theme, &inputs, true); err != nil {
 		return err
 	}
 
 	// Phase 5: Done.
-	printSummary(out, theme, inputs)
+	printSummary(out, theme, inputs, opts.RevealSecrets)
+	if !opts.RevealSecrets && !inputs.SecretsWereSet {
+		return offerSecretCopy(out, theme, inputs)
+	}
+	return nil
+}
+
+// normalizeKeyInput rewrites a .p8 key in place as single-encoded base64 PEM
+// and warns when the input had to be fixed. Blank keys are left alone.
+func normalizeKeyInput(out io.Writer, theme term.Theme, privKeyB64 *string) error {
+	if strings.TrimSpace(*privKeyB64) == "" {
+		return nil
+	}
+	normalized, note, err := normalizePrivateKey(*privKeyB64)
+	if err != nil {
+		fmt.Fprintf(out, "%s Invalid private key: %v\n\n", theme.Error("✗"), err)
+		return fmt.Errorf("invalid ASC private key: %w", err)
+	}
+	if note != "" {
+		fmt.Fprintf(out, "%s %s\n\n", theme.Muted("○"), note)
+	}
+	*privKeyB64 = normalized
+	return nil
+}
+
+// applyGitHubSecrets sets the ASC secrets on repoSlug and reports each result.
+// Returns true when every secret was set.
+func applyGitHubSecrets(out io.Writer, theme term.Theme, inputs *Inputs, repoSlug string) bool {
+	secrets := map[string]string{
+		"ASC_KEY_ID":          inputs.ASCKeyID,
+		"ASC_ISSUER_ID":       inputs.ASCIssuerID,
+		"ASC_PRIVATE_KEY_B64": inputs.ASCPrivateKeyB64,
+	}
+	errs := SetGitHubSecrets(repoSlug, secrets)
+
+	allOK := true
+	for _, name := range requiredSecretNames {
+		if setErr := errs[name]; setErr != nil {
+			fmt.Fprintf(out, "  %s Failed to set %s: %v\n", theme.Error("✗"), name, setErr)
+			allOK = false
+		} else {
+			fmt.Fprintf(out, "  %s %s set\n", theme.Success("✓"), name)
+		}
+	}
+	if allOK {
+		inputs.SecretsWereSet = true
+	}
+	fmt.Fprintln(out)
+	return allOK
+}
+
+// applyGitHubVariables creates or updates the app variables on repoSlug and
+// reports each result. Returns true when every variable was set.
+func applyGitHubVariables(out io.Writer, theme term.Theme, inputs *Inputs, repoSlug string) bool {
+	variables := map[string]string{
+		"ASC_APP_ID":  inputs.AppID,
+		"ASC_TEAM_ID": inputs.TeamID,
+		"BUNDLE_ID":   inputs.BundleID,
+	}
+	errs := SetGitHubVariables(repoSlug, variables)
+
+	allOK := true
+	for _, name := range requiredVariableNames {
+		if setErr := errs[name]; setErr != nil {
+			fmt.Fprintf(out, "  %s Failed to set %s: %v\n", theme.Error("✗"), name, setErr)
+			allOK = false
+		} else {
+			fmt.Fprintf(out, "  %s %s set\n", theme.Success("✓"), name)
+		}
+	}
+	if allOK {
+		inputs.VariablesWereSet = true
+	}
+	fmt.Fprintln(out)
+	return allOK
+}
+
+// writeWorkflowFile renders the release workflow to inputs.WorkflowPath.
+func writeWorkflowFile(out io.Writer, theme term.Theme, inputs *Inputs) error {
+	content, err := GenerateWorkflow(inputs.WorkflowOptions())
+	if err != nil {
+		return fmt.Errorf("failed to generate workflow: %w", err)
+	}
+	return saveWorkflow(out, theme, inputs, content)
+}
+
+// saveWorkflow writes content to inputs.WorkflowPath and reports it.
+func saveWorkflow(out io.Writer, theme term.Theme, inputs *Inputs, content string) error {
+	if err := WriteWorkflow(inputs.WorkflowPath, content); err != nil {
+		return fmt.Errorf("failed to write workflow: %w", err)
+	}
+	inputs.WorkflowWasWritten = true
+	fmt.Fprintf(out, "  %s %s written\n", theme.Success("✓"), inputs.WorkflowPath)
+	fmt.Fprintln(out)
 	return nil
 }
+
+// loadSavedConfig returns the project config when one exists. An invalid file
+// is reported and ignored so that the wizard can rewrite it.
+func loadSavedConfig(out io.Writer, theme term.Theme) Config {
+	if !fileExists(DefaultConfigPath) {
+		return Config{}
+	}
+	cfg, err := LoadConfig(DefaultConfigPath)
+	if err != nil {
+		fmt.Fprintf(out, "%s Ignoring saved settings: %v\n\n", theme.Muted("○"), err)
+		return Config{}
+	}
+	fmt.Fprintf(out, "%s Loaded saved settings from %s\n\n", theme.Success("✓"), DefaultConfigPath)
+	return cfg
+}
+
+// saveProjectConfig writes the non-secret inputs to the project config so the
+// next run starts from them. Nothing is written when the file already holds
+// these values; with confirm set, the user is asked first, since rewriting the
+// file drops its comments. Failure to write is reported but not fatal.
+func saveProjectConfig(out io.Writer, theme term.Theme, inputs *Inputs, confirm bool) error {
+	cfg := ConfigFromInputs(*inputs)
+	if configUpToDate(DefaultConfigPath, cfg) {
+		inputs.ConfigPath = DefaultConfigPath
+		fmt.Fprintf(out, "  %s %s is up to date\n\n", theme.Muted("○"), DefaultConfigPath)
+		return nil
+	}
+
+	if confirm {
+		description := "Later runs pre-fill from it; check, doctor and apply read it too."
+		if fileExists(DefaultConfigPath) {
+			description = "The file is rewritten from these settings; comments in it are not kept."
+		}
+		save := true
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title("Save these settings to "+DefaultConfigPath+"?").
+					Description(description).
+					Affirmative("Save").
+					Negative("Skip").
+					Inline(true).
+					Value(&save),
+			),
+		).WithTheme(huh.ThemeCharm())
+		if err := form.Run(); err != nil {
+			if errors.Is(err, huh.ErrUserAborted) {
+				return fmt.Errorf("wizard canceled")
+			}
+			return err
+		}
+		if !save {
+			fmt.Fprintf(out, "  %s %s not written\n\n", theme.Muted("○"), DefaultConfigPath)
+			return nil
+		}
+	}
+
+	if err := SaveConfig(DefaultConfigPath, cfg); err != nil {
+		fmt.Fprintf(out, "  %s Failed to write %s: %v\n\n", theme.Error("✗"), DefaultConfigPath, err)
+		return nil
+	}
+	inputs.ConfigPath = DefaultConfigPath
+	fmt.Fprintf(out, "  %s %s written\n\n", theme.Success("✓"), DefaultConfigPath)
+	return nil
+}
+
+// fileExists reports whether path exists on disk.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
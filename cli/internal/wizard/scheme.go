@@ -0,0 +1,185 @@
+package wizard
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SchemeFile is an .xcscheme found in a workspace or project. Only shared
+// schemes (under xcshareddata) are committed, so only they exist on CI.
+type SchemeFile struct {
+	Name                 string
+	Path                 string
+	Shared               bool
+	ArchiveConfiguration string // ArchiveAction buildConfiguration, e.g. "Release"
+	BuildableName        string // product archived by the scheme, e.g. "App.app"
+	BlueprintName        string // target that builds it
+	ReferencedContainer  string // e.g. "container:App.xcodeproj"
+}
+
+type xcscheme struct {
+	BuildAction struct {
+		Entries []struct {
+			BuildForArchiving string             `xml:"buildForArchiving,attr"`
+			Reference         buildableReference `xml:"BuildableReference"`
+		} `xml:"BuildActionEntries>BuildActionEntry"`
+	} `xml:"BuildAction"`
+	ArchiveAction struct {
+		BuildConfiguration string `xml:"buildConfiguration,attr"`
+	} `xml:"ArchiveAction"`
+}
+
+type buildableReference struct {
+	BuildableName       string `xml:"BuildableName,attr"`
+	BlueprintName       string `xml:"BlueprintName,attr"`
+	ReferencedContainer string `xml:"ReferencedContainer,attr"`
+}
+
+// ParseSchemeFile reads an .xcscheme. The buildable reference is the first
+// entry built for archiving that produces an .app, or else the first entry
+// built for archiving.
+func ParseSchemeFile(path string) (SchemeFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return SchemeFile{}, err
+	}
+	var doc xcscheme
+	if err := xml.Unmarshal(content, &doc); err != nil {
+		return SchemeFile{}, err
+	}
+
+	scheme := SchemeFile{
+		Name:                 strings.TrimSuffix(filepath.Base(path), ".xcscheme"),
+		Path:                 path,
+		Shared:               strings.Contains(filepath.ToSlash(path), "/xcshareddata/"),
+		ArchiveConfiguration: doc.ArchiveAction.BuildConfiguration,
+	}
+	var ref *buildableReference
+	for i, entry := range doc.BuildAction.Entries {
+		if entry.BuildForArchiving != "YES" {
+			continue
+		}
+		if ref == nil || (!strings.HasSuffix(ref.BuildableName, ".app") && strings.HasSuffix(entry.Reference.BuildableName, ".app")) {
+			ref = &doc.BuildAction.Entries[i].Reference
+		}
+	}
+	if ref != nil {
+		scheme.BuildableName = ref.BuildableName
+		scheme.BlueprintName = ref.BlueprintName
+		scheme.ReferencedContainer = ref.ReferencedContainer
+	}
+	return scheme, nil
+}
+
+// FindSchemeFiles lists the schemes of a workspace or standalone project
+// without xcodebuild: xcshareddata/xcschemes and every user's
+// xcuserdata/*.xcuserdatad/xcschemes, for the container itself and, for a
+// workspace, each project it references. A scheme shared anywhere hides the
+// user-only copies of the same name. Sorted by name; unreadable files are
+// skipped.
+func FindSchemeFiles(workspacePath string) []SchemeFile {
+	containers := []string{workspacePath}
+	if ProjectKindForPath(workspacePath) == KindWorkspace {
+		projects, _ := WorkspaceProjects(workspacePath)
+		containers = append(containers, projects...)
+	}
+
+	var paths []string
+	for _, container := range containers {
+		shared, _ := filepath.Glob(filepath.Join(container, "xcshareddata", "xcschemes", "*.xcscheme"))
+		user, _ := filepath.Glob(filepath.Join(container, "xcuserdata", "*.xcuserdatad", "xcschemes", "*.xcscheme"))
+		paths = append(paths, shared...)
+		paths = append(paths, user...)
+	}
+
+	byName := make(map[string]SchemeFile)
+	for _, path := range paths {
+		scheme, err := ParseSchemeFile(path)
+		if err != nil {
+			continue
+		}
+		if existing, ok := byName[scheme.Name]; ok && (existing.Shared || !scheme.Shared) {
+			continue
+		}
+		byName[scheme.Name] = scheme
+	}
+
+	schemes := make([]SchemeFile, 0, len(byName))
+	for _, scheme := range byName {
+		schemes = append(schemes, scheme)
+	}
+	sort.Slice(schemes, func(i, j int) bool { return schemes[i].Name < schemes[j].Name })
+	return schemes
+}
+
+// unsharedSchemes returns the names of the user-only schemes, which CI runners
+// cannot see.
+func unsharedSchemes(schemes []SchemeFile) map[string]bool {
+	unshared := make(map[string]bool)
+	for _, scheme := range schemes {
+		if !scheme.Shared {
+			unshared[scheme.Name] = true
+		}
+	}
+	return unshared
+}
+
+// findSchemeFile returns the scheme file named name, preferring a shared copy.
+func findSchemeFile(workspacePath, name string) (SchemeFile, bool) {
+	for _, scheme := range FindSchemeFiles(workspacePath) {
+		if scheme.Name == name {
+			return scheme, true
+		}
+	}
+	return SchemeFile{}, false
+}
+
+// sharedSchemePath maps a user scheme
+// (X.xcodeproj/xcuserdata/me.xcuserdatad/xcschemes/App.xcscheme) to where
+// Xcode keeps it once shared (X.xcodeproj/xcshareddata/xcschemes/App.xcscheme).
+func sharedSchemePath(userPath string) string {
+	container := filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(userPath))))
+	return filepath.Join(container, "xcshareddata", "xcschemes", filepath.Base(userPath))
+}
+
+// shareScheme copies a user-only scheme into xcshareddata, which is what
+// Xcode's "Shared" checkbox does, and returns the new path.
+func shareScheme(scheme SchemeFile) (string, error) {
+	content, err := os.ReadFile(scheme.Path)
+	if err != nil {
+		return "", err
+	}
+	dest := sharedSchemePath(scheme.Path)
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(dest, content, 0644); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// checkSchemeShared reports whether the scheme is committed where CI runners
+// can see it. A scheme with no file at all is only a warning: xcodebuild
+// autocreates schemes for projects that have none.
+func checkSchemeShared(workspacePath, name string) CheckResult {
+	result := CheckResult{Name: "Scheme shared"}
+	scheme, ok := findSchemeFile(workspacePath, name)
+	switch {
+	case !ok:
+		result.Status = CheckWarn
+		result.Detail = "no " + name + ".xcscheme found in xcshareddata"
+		result.Hint = "In Xcode, Product → Scheme → Manage Schemes…, tick Shared and commit the scheme"
+	case !scheme.Shared:
+		result.Status = CheckFail
+		result.Detail = name + " exists only in xcuserdata, so CI runners cannot see it"
+		result.Hint = "Run: releasekit-ios wizard (offers to share it), or tick Shared in Xcode's Manage Schemes and commit " + sharedSchemePath(scheme.Path)
+	default:
+		result.Status = CheckPass
+		result.Detail = scheme.Path
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package wizard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testScheme = `<?xml version="1.0" encoding="UTF-8"?>
+<Scheme LastUpgradeVersion = "1500" version = "1.7">
+   <BuildAction parallelizeBuildables = "YES" buildImplicitDependencies = "YES">
+      <BuildActionEntries>
+         <BuildActionEntry buildForTesting = "YES" buildForRunning = "NO" buildForProfiling = "NO" buildForArchiving = "NO" buildForAnalyzing = "NO">
+            <BuildableReference BuildableIdentifier = "primary" BuildableName = "AppTests.xctest" BlueprintName = "AppTests" ReferencedContainer = "container:App.xcodeproj">
+            </BuildableReference>
+         </BuildActionEntry>
+         <BuildActionEntry buildForTesting = "YES" buildForRunning = "YES" buildForProfiling = "YES" buildForArchiving = "YES" buildForAnalyzing = "YES">
+            <BuildableReference BuildableIdentifier = "primary" BuildableName = "Kit.framework" BlueprintName = "Kit" ReferencedContainer = "container:App.xcodeproj">
+            </BuildableReference>
+         </BuildActionEntry>
+         <BuildActionEntry buildForTesting = "YES" buildForRunning = "YES" buildForProfiling = "YES" buildForArchiving = "YES" buildForAnalyzing = "YES">
+            <BuildableReference BuildableIdentifier = "primary" BuildableName = "App.app" BlueprintName = "App" ReferencedContainer = "container:App.xcodeproj">
+            </BuildableReference>
+         </BuildActionEntry>
+      </BuildActionEntries>
+   </BuildAction>
+   <ArchiveAction buildConfiguration = "AppStore" revealArchiveInOrganizer = "YES">
+   </ArchiveAction>
+</Scheme>
+`
+
+func writeScheme(t *testing.T, path string) {
+	t.Helper()
+	mkdirs(t, filepath.Dir(path))
+	if err := os.WriteFile(path, []byte(testScheme), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseSchemeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "App.xcodeproj", "xcshareddata", "xcschemes", "App.xcscheme")
+	writeScheme(t, path)
+
+	scheme, err := ParseSchemeFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := SchemeFile{
+		Name:                 "App",
+		Path:                 path,
+		Shared:               true,
+		ArchiveConfiguration: "AppStore",
+		BuildableName:        "App.app",
+		BlueprintName:        "App",
+		ReferencedContainer:  "container:App.xcodeproj",
+	}
+	if scheme != want {
+		t.Errorf("ParseSchemeFile() = %+v, want %+v", scheme, want)
+	}
+}
+
+func TestFindSchemeFiles(t *testing.T) {
+	root := t.TempDir()
+	ws := filepath.Join(root, "App.xcworkspace")
+	writeWorkspace(t, ws, `<FileRef location = "group:App.xcodeproj"></FileRef>`)
+	project := filepath.Join(root, "App.xcodeproj")
+	writeScheme(t, filepath.Join(project, "xcshareddata", "xcschemes", "App.xcscheme"))
+	writeScheme(t, filepath.Join(project, "xcuserdata", "me.xcuserdatad", "xcschemes", "App.xcscheme"))
+	writeScheme(t, filepath.Join(project, "xcuserdata", "me.xcuserdatad", "xcschemes", "Staging.xcscheme"))
+	writeScheme(t, filepath.Join(ws, "xcshareddata", "xcschemes", "All.xcscheme"))
+
+	schemes := FindSchemeFiles(ws)
+	if len(schemes) != 3 {
+		t.Fatalf("expected 3 schemes, got %+v", schemes)
+	}
+	for i, want := range []struct {
+		name   string
+		shared bool
+	}{{"All", true}, {"App", true}, {"Staging", false}} {
+		if schemes[i].Name != want.name || schemes[i].Shared != want.shared {
+			t.Errorf("schemes[%d] = %s (shared %v), want %s (shared %v)", i, schemes[i].Name, schemes[i].Shared, want.name, want.shared)
+		}
+	}
+	if unshared := unsharedSchemes(schemes); len(unshared) != 1 || !unshared["Staging"] {
+		t.Errorf("unsharedSchemes() = %v, want only Staging", unshared)
+	}
+}
+
+func TestFindSchemeFilesStandaloneProject(t *testing.T) {
+	project := filepath.Join(t.TempDir(), "Tool.xcodeproj")
+	writeScheme(t, filepath.Join(project, "xcshareddata", "xcschemes", "Tool.xcscheme"))
+
+	if schemes := FindSchemeFiles(project); len(schemes) != 1 || schemes[0].Name != "Tool" {
+		t.Errorf("FindSchemeFiles() = %+v, want Tool", schemes)
+	}
+}
+
+func TestShareSchemeAndCheckSchemeShared(t *testing.T) {
+	project := filepath.Join(t.TempDir(), "App.xcodeproj")
+	userPath := filepath.Join(project, "xcuserdata", "me.xcuserdatad", "xcschemes", "App.xcscheme")
+	writeScheme(t, userPath)
+
+	if got := checkSchemeShared(project, "App"); got.Status != CheckFail {
+		t.Errorf("user-only scheme: status = %v, want fail (%s)", got.Status, got.Detail)
+	}
+	if got := checkSchemeShared(project, "Missing"); got.Status != CheckWarn {
+		t.Errorf("missing scheme: status = %v, want warn", got.Status)
+	}
+
+	scheme, _ := findSchemeFile(project, "App")
+	path, err := shareScheme(scheme)
+	if err != nil {
+		t.Fatalf("shareScheme: %v", err)
+	}
+	if want := filepath.Join(project, "xcshareddata", "xcschemes", "App.xcscheme"); path != want {
+		t.Errorf("shareScheme() = %q, want %q", path, want)
+	}
+	if got := checkSchemeShared(project, "App"); got.Status != CheckPass || got.Detail != path {
+		t.Errorf("after sharing: %+v", got)
+	}
+}
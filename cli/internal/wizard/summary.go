@@ -3,11 +3,14 @@ package wizard
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/vinceglb/releasekit-ios/cli/internal/term"
 )
 
-func printSummary(out io.Writer, theme term.Theme, inputs Inputs) {
+// printSummary prints the collected values and the remaining manual steps.
+// Secret values are masked unless revealSecrets is set.
+func printSummary(out io.Writer, theme term.Theme, inputs Inputs, revealSecrets bool) {
 	fmt.Fprintln(out)
 	fmt.Fprintln(out, theme.Section("Wizard Completed"))
 	fmt.Fprintln(out, theme.Muted("Setup values collected successfully."))
@@ -17,25 +20,54 @@ func printSummary(out io.Writer, theme term.Theme, inputs Inputs) {
 	if inputs.AppName != "" {
 		printKV(out, theme, "App Name", inputs.AppName)
 	}
-	printKV(out, theme, "Workspace", inputs.Workspace)
+	printKV(out, theme, kindTitle(inputs.Kind()), inputs.Workspace)
 	printKV(out, theme, "Scheme", inputs.Scheme)
 	printKV(out, theme, "Bundle ID", inputs.BundleID)
 	printKV(out, theme, "Team ID", inputs.TeamID)
 	printKV(out, theme, "App ID", inputs.AppID)
 	fmt.Fprintln(out)
 
+	if len(inputs.Extensions) > 0 {
+		fmt.Fprintln(out, theme.Section("Embedded Targets"))
+		for _, ext := range inputs.Extensions {
+			var status string
+			switch ext.Registered {
+			case RegistrationFound:
+				status = theme.Success("✓ registered")
+			case RegistrationMissing:
+				status = theme.Failure("✗ not registered in App Store Connect")
+			default:
+				status = theme.Muted("not checked")
+			}
+			fmt.Fprintf(out, "  %s %s  %s\n", theme.Label(fmt.Sprintf("%-12s", ext.Target+":")), theme.Value(ext.BundleID), status)
+		}
+		fmt.Fprintln(out)
+	}
+
 	secretNote := ""
 	if inputs.SecretsWereSet {
 		secretNote = " " + theme.Success("(✓ set automatically)")
 	}
 
 	fmt.Fprintln(out, theme.Section("GitHub Secrets"+secretNote))
-	fmt.Fprintf(out, "  %s\n", theme.Value("ASC_KEY_ID="+inputs.ASCKeyID))
-	fmt.Fprintf(out, "  %s\n", theme.Value("ASC_ISSUER_ID="+inputs.ASCIssuerID))
-	fmt.Fprintf(out, "  %s\n", theme.Value("ASC_PRIVATE_KEY_B64="+inputs.ASCPrivateKeyB64))
+	for _, secret := range summarySecrets(inputs) {
+		value := secret.value
+		if !revealSecrets {
+			value = maskSecret(value)
+		}
+		fmt.Fprintf(out, "  %s\n", theme.Value(secret.name+"="+value))
+	}
+	if !revealSecrets {
+		fmt.Fprintln(out, theme.Muted("  Values are masked; rerun with --reveal-secrets to print them in full."))
+	}
 	fmt.Fprintln(out)
 
-	fmt.Fprintln(out, theme.Section("GitHub Variables"))
+	variableNote := ""
+	if inputs.VariablesWereSet {
+		variableNote = " " + theme.Success("(✓ set automatically)")
+	}
+
+	fmt.Fprintln(out, theme.Section("GitHub Variables"+variableNote))
 	fmt.Fprintf(out, "  %s\n", theme.Value("ASC_APP_ID="+inputs.AppID))
 	fmt.Fprintf(out, "  %s\n", theme.Value("ASC_TEAM_ID="+inputs.TeamID))
 	fmt.Fprintf(out, "  %s\n", theme.Value("BUNDLE_ID="+inputs.BundleID))
@@ -44,12 +76,15 @@ func printSummary(out io.Writer, theme term.Theme, inputs Inputs) {
 	fmt.Fprintln(out, theme.Section("Next Steps"))
 	stepNum := 1
 
+	if missing := unregisteredExtensions(inputs.Extensions); len(missing) > 0 {
+		fmt.Fprintf(out, theme.Muted("  %d) Register %s in App Store Connect (Identifiers)\n"), stepNum, strings.Join(missing, ", "))
+		stepNum++
+	}
 	if !inputs.SecretsWereSet {
 		fmt.Fprintf(out, theme.Muted("  %d) Add the GitHub Secrets above to your repository\n"), stepNum)
 		stepNum++
-		fmt.Fprintf(out, theme.Muted("  %d) Add the GitHub Variables above to your repository\n"), stepNum)
-		stepNum++
-	} else {
+	}
+	if !inputs.VariablesWereSet {
 		fmt.Fprintf(out, theme.Muted("  %d) Add the GitHub Variables above to your repository\n"), stepNum)
 		stepNum++
 	}
@@ -62,9 +97,42 @@ func printSummary(out io.Writer, theme term.Theme, inputs Inputs) {
 		stepNum++
 	}
 
+	if inputs.ConfigPath != "" {
+		fmt.Fprintf(out, theme.Muted("  %d) Commit %s so later runs start from these values\n"), stepNum, inputs.ConfigPath)
+		stepNum++
+	}
+
 	fmt.Fprintf(out, theme.Muted("  %d) Push a v* tag to trigger your release\n"), stepNum)
 }
 
+type summarySecret struct {
+	name  string
+	value string
+}
+
+// summarySecrets lists the GitHub secrets in requiredSecretNames order.
+func summarySecrets(inputs Inputs) []summarySecret {
+	return []summarySecret{
+		{name: "ASC_KEY_ID", value: inputs.ASCKeyID},
+		{name: "ASC_ISSUER_ID", value: inputs.ASCIssuerID},
+		{name: "ASC_PRIVATE_KEY_B64", value: inputs.ASCPrivateKeyB64},
+	}
+}
+
+// maskSecret keeps the first and last 4 characters of value and replaces the
+// rest with an ellipsis. Values too short to keep both ends are fully masked.
+func maskSecret(value string) string {
+	const keep = 4
+	runes := []rune(value)
+	if len(runes) == 0 {
+		return ""
+	}
+	if len(runes) <= 3*keep {
+		return strings.Repeat("•", len(runes))
+	}
+	return string(runes[:keep]) + "…" + string(runes[len(runes)-keep:])
+}
+
 func printKV(out io.Writer, theme term.Theme, label, value string) {
 	fmt.Fprintf(out, "  %s %s\n", theme.Label(fmt.Sprintf("%-12s", label+":")), theme.Value(value))
 }
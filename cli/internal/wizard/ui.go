@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/huh"
 	"github.com/vinceglb/releasekit-ios/cli/internal/term"
 )
@@ -35,12 +36,12 @@ func collectPhase1ASCCredentials() (issuerID, keyID, privKeyB64 string, err erro
 				Title("Issuer ID").
 				Placeholder("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx").
 				Value(&issuerID).
-				Validate(requiredField("Issuer ID")),
+				Validate(validateIssuerID),
 			huh.NewInput().
 				Title("Key ID").
 				Placeholder("ABC123XY45").
 				Value(&keyID).
-				Validate(requiredField("Key ID")),
+				Validate(validateKeyID),
 			huh.NewSelect[string]().
 				Title("Private key source").
 				Options(
@@ -63,7 +64,7 @@ func collectPhase1ASCCredentials() (issuerID, keyID, privKeyB64 string, err erro
 				Title("Base64 private key").
 				Placeholder("LS0tLS1CRUdJTi...").
 				Value(&privKeyB64).
-				Validate(requiredField("Base64 private key")),
+				Validate(privateKeyField),
 		).WithHideFunc(func() bool { return keySource != "base64" }),
 	).WithTheme(huh.ThemeCharm())
 
@@ -85,17 +86,19 @@ func collectPhase1ASCCredentials() (issuerID, keyID, privKeyB64 string, err erro
 		}
 		privKeyB64 = encoded
 	case "base64":
-		privKeyB64 = normalizeBase64(privKeyB64)
+		privKeyB64 = strings.TrimSpace(privKeyB64)
 	}
 
 	return strings.TrimSpace(issuerID), strings.TrimSpace(keyID), privKeyB64, nil
 }
 
-// collectPhase2App shows app selection from a pre-fetched list, or falls back
-// to manual input when no apps are available.
-func collectPhase2App(apps []ASCApp) (appName, appID, bundleID string, err error) {
+// collectPhase2App shows a filterable app picker from a pre-fetched list, or
+// falls back to manual input when no apps are available. The app matching a
+// bundle ID detected in the Xcode project is pre-selected, then the one saved
+// in the project config.
+func collectPhase2App(apps []ASCApp, detectedBundleIDs []string, saved Config) (appName, appID, bundleID string, err error) {
 	if len(apps) > 0 {
-		var selectedAppID string
+		selectedAppID := preselectAppID(apps, detectedBundleIDs, saved.AppID)
 		appMap := make(map[string]ASCApp, len(apps))
 		options := make([]huh.Option[string], 0, len(apps))
 		for _, app := range apps {
@@ -108,8 +111,10 @@ func collectPhase2App(apps []ASCApp) (appName, appID, bundleID string, err error
 			huh.NewGroup(
 				huh.NewSelect[string]().
 					Title("Select your app").
-					Description("Fetched from App Store Connect").
+					Description("Fetched from App Store Connect — type to filter by name or bundle ID").
 					Options(options...).
+					Filtering(true).
+					Height(min(len(options), appPickerHeight)+2).
 					Value(&selectedAppID),
 			),
 		).WithTheme(huh.ThemeCharm())
@@ -126,18 +131,19 @@ func collectPhase2App(apps []ASCApp) (appName, appID, bundleID string, err error
 	}
 
 	// Fallback: manual input.
+	appID, bundleID = saved.AppID, saved.BundleID
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewInput().
 				Title("App Store Connect App ID").
 				Description("Numeric app ID from App Store Connect").
 				Value(&appID).
-				Validate(requiredField("App ID")),
+				Validate(validateAppID),
 			huh.NewInput().
 				Title("Bundle ID").
 				Placeholder("com.example.myapp").
 				Value(&bundleID).
-				Validate(requiredField("Bundle ID")),
+				Validate(validateBundleID),
 		),
 	).WithTheme(huh.ThemeCharm())
 
@@ -151,17 +157,45 @@ func collectPhase2App(apps []ASCApp) (appName, appID, bundleID string, err error
 	return "", strings.TrimSpace(appID), strings.TrimSpace(bundleID), nil
 }
 
+// appPickerHeight caps the number of apps visible at once in the picker.
+const appPickerHeight = 10
+
+// preselectAppID returns savedAppID when that app is still listed, and
+// otherwise the ID of the first app whose bundle ID was detected in the Xcode
+// project.
+func preselectAppID(apps []ASCApp, detectedBundleIDs []string, savedAppID string) string {
+	for _, app := range apps {
+		if savedAppID != "" && app.ID == savedAppID {
+			return app.ID
+		}
+	}
+	for _, bundleID := range detectedBundleIDs {
+		for _, app := range apps {
+			if app.Attributes.BundleID == bundleID {
+				return app.ID
+			}
+		}
+	}
+	return ""
+}
+
 // collectPhase3Xcode collects Xcode project settings using detected candidates,
-// schemes, and team ID. prefillBundleID is pre-filled from Phase 2.
+// schemes, and team ID. Schemes in unshared are labelled as user-only.
+// prefillBundleID is pre-filled from Phase 2; saved provides defaults from the
+// project config.
 func collectPhase3Xcode(
 	candidates []string,
 	schemes []string,
+	unshared map[string]bool,
 	detectedTeamID string,
 	prefillBundleID string,
+	saved Config,
 ) (workspace, scheme, teamID, bundleID string, err error) {
-	var useDetectedTeam bool = true
-	var teamInput string
+	var useDetectedTeam bool = detectedTeamID == saved.TeamID || saved.TeamID == ""
+	teamInput := saved.TeamID
 	bundleID = prefillBundleID
+	workspace = saved.Inputs().Workspace
+	scheme = saved.Scheme
 
 	var groups []*huh.Group
 
@@ -170,16 +204,16 @@ func collectPhase3Xcode(
 	case 0:
 		groups = append(groups, huh.NewGroup(
 			huh.NewInput().
-				Title("Xcode workspace path").
-				Description("Path to your .xcworkspace").
+				Title("Xcode workspace or project path").
+				Description("Path to your .xcworkspace, or .xcodeproj if the app has no workspace").
 				Value(&workspace).
-				Validate(requiredField("Xcode workspace path")),
+				Validate(requiredField("Xcode workspace or project path")),
 		))
 	case 1:
 		workspace = candidates[0]
 		groups = append(groups, huh.NewGroup(
 			huh.NewNote().
-				Title("Xcode Workspace").
+				Title("Xcode "+kindTitle(ProjectKindForPath(candidates[0]))).
 				Description("Detected: "+candidates[0]),
 		))
 	default:
@@ -189,7 +223,7 @@ func collectPhase3Xcode(
 		}
 		groups = append(groups, huh.NewGroup(
 			huh.NewSelect[string]().
-				Title("Select Xcode workspace").
+				Title("Select Xcode workspace or project").
 				Options(opts...).
 				Value(&workspace),
 		))
@@ -206,7 +240,11 @@ func collectPhase3Xcode(
 	} else {
 		opts := make([]huh.Option[string], len(schemes))
 		for i, s := range schemes {
-			opts[i] = huh.NewOption(s, s)
+			label := s
+			if unshared[s] {
+				label += " (not shared; CI cannot see it)"
+			}
+			opts[i] = huh.NewOption(label, s)
 		}
 		groups = append(groups, huh.NewGroup(
 			huh.NewSelect[string]().
@@ -232,7 +270,7 @@ func collectPhase3Xcode(
 					Title("Apple Team ID").
 					Placeholder("XXXXXXXXXX").
 					Value(&teamInput).
-					Validate(requiredField("Apple Team ID")),
+					Validate(validateTeamID),
 			).WithHideFunc(func() bool { return useDetectedTeam }),
 		)
 	} else {
@@ -241,7 +279,7 @@ func collectPhase3Xcode(
 				Title("Apple Team ID").
 				Placeholder("XXXXXXXXXX").
 				Value(&teamInput).
-				Validate(requiredField("Apple Team ID")),
+				Validate(validateTeamID),
 		))
 	}
 
@@ -251,7 +289,7 @@ func collectPhase3Xcode(
 			Title("Bundle ID").
 			Placeholder("com.example.myapp").
 			Value(&bundleID).
-			Validate(requiredField("Bundle ID")),
+			Validate(validateBundleID),
 	))
 
 	form := huh.NewForm(groups...).WithTheme(huh.ThemeCharm())
@@ -273,21 +311,21 @@ func collectPhase3Xcode(
 }
 
 // collectPhase4GitHub handles GitHub secret setting and optional workflow generation.
-func collectPhase4GitHub(out io.Writer, theme term.Theme, inputs *Inputs, ghAuthed bool, owner, repo string) error {
+func collectPhase4GitHub(out io.Writer, theme term.Theme, inputs *Inputs, canSetGitHub bool, owner, repo string) error {
 	repoSlug := ""
 	if owner != "" && repo != "" {
 		repoSlug = owner + "/" + repo
 		inputs.GitHubRepo = repoSlug
 	}
 
-	// Ask to auto-set secrets.
+	// Ask to auto-set secrets and variables.
 	var wantAutoSecrets bool
-	if ghAuthed && repoSlug != "" {
+	if canSetGitHub && repoSlug != "" {
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewConfirm().
-					Title(fmt.Sprintf("Set GitHub secrets automatically for %s?", repoSlug)).
-					Affirmative("Yes, use gh CLI").
+					Title(fmt.Sprintf("Set GitHub secrets and variables automatically for %s?", repoSlug)).
+					Affirmative("Yes, set them now").
 					Negative("I'll do it manually").
 					Inline(true).
 					Value(&wantAutoSecrets),
@@ -302,34 +340,19 @@ func collectPhase4GitHub(out io.Writer, theme term.Theme, inputs *Inputs, ghAuth
 	}
 
 	if wantAutoSecrets {
-		secrets := map[string]string{
-			"ASC_KEY_ID":          inputs.ASCKeyID,
-			"ASC_ISSUER_ID":       inputs.ASCIssuerID,
-			"ASC_PRIVATE_KEY_B64": inputs.ASCPrivateKeyB64,
-		}
-		errs := SetGitHubSecrets(repoSlug, secrets)
-
-		allOK := true
-		for name, setErr := range errs {
-			if setErr != nil {
-				fmt.Fprintf(out, "  %s Failed to set %s: %v\n", theme.Error("✗"), name, setErr)
-				allOK = false
-			} else {
-				fmt.Fprintf(out, "  %s %s set\n", theme.Success("✓"), name)
-			}
-		}
-		if allOK {
-			inputs.SecretsWereSet = true
-		}
-		fmt.Fprintln(out)
+		applyGitHubSecrets(out, theme, inputs, repoSlug)
+		applyGitHubVariables(out, theme, inputs, repoSlug)
 	}
 
 	// Ask to generate workflow file.
+	if inputs.WorkflowPath == "" {
+		inputs.WorkflowPath = DefaultWorkflowPath()
+	}
 	var wantWorkflow bool
 	workflowForm := huh.NewForm(
 		huh.NewGroup(
 			huh.NewConfirm().
-				Title("Generate .github/workflows/release.yml?").
+				Title(fmt.Sprintf("Generate %s?", inputs.WorkflowPath)).
 				Affirmative("Yes").
 				Negative("No, I'll write it myself").
 				Inline(true).
@@ -344,48 +367,199 @@ func collectPhase4GitHub(out io.Writer, theme term.Theme, inputs *Inputs, ghAuth
 	}
 
 	if wantWorkflow {
-		workflowPath := DefaultWorkflowPath()
-		inputs.WorkflowPath = workflowPath
+		if err := collectWorkflowOptions(inputs); err != nil {
+			return err
+		}
+		content, err := GenerateWorkflow(inputs.WorkflowOptions())
+		if err != nil {
+			return fmt.Errorf("failed to generate workflow: %w", err)
+		}
 
 		// Check if file already exists.
-		if _, statErr := os.Stat(workflowPath); statErr == nil {
-			var overwrite bool
-			overwriteForm := huh.NewForm(
-				huh.NewGroup(
-					huh.NewConfirm().
-						Title(workflowPath+" already exists. Overwrite?").
-						Affirmative("Yes, overwrite").
-						Negative("No, keep existing").
-						Inline(true).
-						Value(&overwrite),
-				),
-			).WithTheme(huh.ThemeCharm())
-			if err := overwriteForm.Run(); err != nil {
-				if errors.Is(err, huh.ErrUserAborted) {
-					return fmt.Errorf("wizard canceled")
-				}
-				return err
-			}
-			if !overwrite {
-				return nil
-			}
+		if existing, readErr := os.ReadFile(inputs.WorkflowPath); readErr == nil {
+			return resolveExistingWorkflow(out, theme, inputs, string(existing), content)
+		}
+		if err := saveWorkflow(out, theme, inputs, content); err != nil {
+			return err
 		}
+	}
 
-		content, err := GenerateWorkflow(*inputs)
-		if err != nil {
-			return fmt.Errorf("failed to generate workflow: %w", err)
+	return nil
+}
+
+// resolveExistingWorkflow shows a diff between the workflow on disk and the
+// generated one, then lets the user update only the ReleaseKit steps, write
+// the new workflow next to the old one, overwrite it, or keep it.
+func resolveExistingWorkflow(out io.Writer, theme term.Theme, inputs *Inputs, existing, generated string) error {
+	path := inputs.WorkflowPath
+	if existing == generated {
+		fmt.Fprintf(out, "  %s %s is up to date\n\n", theme.Success("✓"), path)
+		return nil
+	}
+
+	fmt.Fprintf(out, "%s %s differs from the generated workflow:\n\n", theme.Failure("!"), path)
+	fmt.Fprint(out, colorDiff(theme, UnifiedDiff(path, path+" (generated)", existing, generated)))
+	fmt.Fprintln(out)
+
+	// A side file without a .yml extension is not picked up by GitHub Actions.
+	sidePath := path + ".new"
+	merged, missing, mergeErr := MergeWorkflow(existing, generated)
+	mergeOption := huh.NewOption("Update the ReleaseKit steps, keep my changes", "merge")
+	sideOption := huh.NewOption("Write the generated workflow to "+sidePath, "side")
+	var options []huh.Option[string]
+	switch {
+	case mergeErr != nil:
+		fmt.Fprintf(out, "%s Cannot update the ReleaseKit steps in place: %v\n\n", theme.Muted("○"), mergeErr)
+		options = append(options, sideOption)
+	case len(missing) > 0:
+		// The merged workflow still needs steps added by hand, so it is not
+		// the default.
+		mergeOption.Key += " (then add " + strings.Join(missing, ", ") + " by hand)"
+		options = append(options, sideOption, mergeOption)
+	default:
+		options = append(options, mergeOption, sideOption)
+	}
+	options = append(options,
+		huh.NewOption("Overwrite with the generated workflow", "overwrite"),
+		huh.NewOption("Keep the existing file", "keep"),
+	)
+
+	choice := options[0].Value
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(path + " already exists").
+				Options(options...).
+				Value(&choice),
+		),
+	).WithTheme(huh.ThemeCharm())
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return fmt.Errorf("wizard canceled")
 		}
-		if err := WriteWorkflow(workflowPath, content); err != nil {
+		return err
+	}
+
+	switch choice {
+	case "merge":
+		if merged == existing {
+			fmt.Fprintf(out, "  %s The ReleaseKit steps in %s are up to date\n\n", theme.Success("✓"), path)
+		} else if err := saveWorkflow(out, theme, inputs, merged); err != nil {
+			return err
+		}
+		for _, name := range missing {
+			fmt.Fprintf(out, "  %s No step uses the %s action; add it from the diff above\n", theme.Failure("!"), name)
+		}
+		if len(missing) > 0 {
+			fmt.Fprintln(out)
+		}
+	case "side":
+		if err := WriteWorkflow(sidePath, generated); err != nil {
 			return fmt.Errorf("failed to write workflow: %w", err)
 		}
-		inputs.WorkflowWasWritten = true
-		fmt.Fprintf(out, "  %s %s written\n", theme.Success("✓"), workflowPath)
-		fmt.Fprintln(out)
+		fmt.Fprintf(out, "  %s %s written; move what you need into %s\n\n", theme.Success("✓"), sidePath, path)
+	case "overwrite":
+		return saveWorkflow(out, theme, inputs, generated)
+	}
+	return nil
+}
+
+// collectWorkflowOptions asks for the runner, Xcode version, action ref,
+// build configuration and extra xcodebuild arguments of the generated
+// workflow. Answers equal to the defaults are stored blank so that the
+// project config only records what was changed.
+func collectWorkflowOptions(inputs *Inputs) error {
+	runnerLabel := inputs.RunnerLabel
+	if runnerLabel == "" {
+		runnerLabel = DefaultRunnerLabel
+	}
+	actionRef := inputs.ActionRef
+	if actionRef == "" {
+		actionRef = DefaultActionRef
+	}
+	configuration := inputs.Configuration
+	if configuration == "" {
+		configuration = DefaultConfiguration
+	}
+	xcodeVersion := inputs.XcodeVersion
+	extraArgs := inputs.XcodebuildExtraArgs
+	layout := inputs.WorkflowLayout
+	if layout == "" {
+		layout = LayoutTwoJobs
 	}
 
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Workflow layout").
+				Description("Two jobs hand the IPA from the archive job to the upload job as an artifact").
+				Options(
+					huh.NewOption("Two jobs: archive, then upload", LayoutTwoJobs),
+					huh.NewOption("Single job: archive and upload on one runner", LayoutSingleJob),
+				).
+				Value(&layout),
+			huh.NewInput().
+				Title("Runner labels").
+				Description("Comma-separated runs-on labels, e.g. self-hosted, macOS, ARM64").
+				Value(&runnerLabel).
+				Validate(func(value string) error {
+					labels := ParseRunnerLabels(value)
+					if len(labels) == 0 {
+						return fmt.Errorf("at least one runner label is required")
+					}
+					return validateRunnerLabels(labels)
+				}),
+			huh.NewInput().
+				Title("Xcode version").
+				Description("Selected before archiving, e.g. 16.2 or latest-stable; blank keeps the runner's default").
+				Value(&xcodeVersion).
+				Validate(func(value string) error {
+					if strings.TrimSpace(value) == "" {
+						return nil
+					}
+					return validateXcodeVersion(strings.TrimSpace(value))
+				}),
+			huh.NewInput().
+				Title("ReleaseKit action ref").
+				Description("Tag, branch or commit SHA of vinceglb/releasekit-ios to pin the actions to").
+				Value(&actionRef).
+				Validate(func(value string) error {
+					return validateActionRef(strings.TrimSpace(value))
+				}),
+			huh.NewInput().
+				Title("Build configuration").
+				Value(&configuration).
+				Validate(requiredField("Build configuration")),
+			huh.NewInput().
+				Title("Extra xcodebuild arguments").
+				Description("Optional, passed to the archive step as-is").
+				Value(&extraArgs),
+		).Title("Workflow options"),
+	).WithTheme(huh.ThemeCharm())
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return fmt.Errorf("wizard canceled")
+		}
+		return err
+	}
+
+	inputs.WorkflowLayout = unlessDefault(layout, LayoutTwoJobs)
+	inputs.RunnerLabel = unlessDefault(strings.Join(ParseRunnerLabels(runnerLabel), ", "), DefaultRunnerLabel)
+	inputs.XcodeVersion = strings.TrimSpace(xcodeVersion)
+	inputs.ActionRef = unlessDefault(strings.TrimSpace(actionRef), DefaultActionRef)
+	inputs.Configuration = unlessDefault(strings.TrimSpace(configuration), DefaultConfiguration)
+	inputs.XcodebuildExtraArgs = strings.TrimSpace(extraArgs)
 	return nil
 }
 
+// unlessDefault returns value, or "" when it equals def.
+func unlessDefault(value, def string) string {
+	if value == def {
+		return ""
+	}
+	return value
+}
+
 // requiredField returns a validation function that rejects blank values.
 func requiredField(label string) func(string) error {
 	return func(value string) error {
@@ -395,3 +569,121 @@ func requiredField(label string) func(string) error {
 		return nil
 	}
 }
+
+// privateKeyField rejects pasted keys that are not a PKCS#8 EC P-256 .p8 key.
+func privateKeyField(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("Base64 private key is required")
+	}
+	_, _, err := normalizePrivateKey(value)
+	return err
+}
+
+// confirmBundleIDMismatch asks whether to keep going after the bundle ID
+// cross-check failed. Declining cancels the wizard before anything is written.
+func confirmBundleIDMismatch() error {
+	proceed := false
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Continue anyway? archive.sh will fail until the IDs match.").
+				Affirmative("Continue").
+				Negative("Cancel and fix it").
+				Inline(true).
+				Value(&proceed),
+		),
+	).WithTheme(huh.ThemeCharm())
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return fmt.Errorf("wizard canceled")
+		}
+		return err
+	}
+	if !proceed {
+		return fmt.Errorf("wizard canceled: bundle ID does not match the Xcode project")
+	}
+	return nil
+}
+
+// offerShareScheme offers to copy the chosen scheme to xcshareddata when it
+// only exists in xcuserdata, where CI runners cannot see it. Declining keeps
+// going with a warning.
+func offerShareScheme(out io.Writer, theme term.Theme, inputs Inputs) error {
+	scheme, ok := findSchemeFile(inputs.Workspace, inputs.Scheme)
+	if !ok || scheme.Shared {
+		return nil
+	}
+	fmt.Fprintf(out, "%s Scheme %s is not shared: it only exists in %s, so CI runners cannot see it.\n\n",
+		theme.Failure("!"), scheme.Name, scheme.Path)
+
+	share := true
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Share the scheme now?").
+				Description("Copies it to "+sharedSchemePath(scheme.Path)+"; commit the new file.").
+				Affirmative("Share it").
+				Negative("Skip").
+				Inline(true).
+				Value(&share),
+		),
+	).WithTheme(huh.ThemeCharm())
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return fmt.Errorf("wizard canceled")
+		}
+		return err
+	}
+	if !share {
+		fmt.Fprintf(out, "  %s Skipped; the archive job will fail until the scheme is shared.\n\n", theme.Muted("○"))
+		return nil
+	}
+
+	path, err := shareScheme(scheme)
+	if err != nil {
+		fmt.Fprintf(out, "  %s Could not share the scheme: %v\n\n", theme.Failure("✗"), err)
+		return nil
+	}
+	fmt.Fprintf(out, "  %s Shared scheme written to %s (commit it)\n\n", theme.Success("✓"), path)
+	return nil
+}
+
+// offerSecretCopy lets the user copy one masked secret at a time to the
+// clipboard, so full values never reach the terminal. It is only offered when
+// the secrets were not set on GitHub automatically.
+func offerSecretCopy(out io.Writer, theme term.Theme, inputs Inputs) error {
+	secrets := summarySecrets(inputs)
+	options := []huh.Option[string]{huh.NewOption("Done", "")}
+	values := make(map[string]string, len(secrets))
+	for _, secret := range secrets {
+		options = append(options, huh.NewOption(secret.name, secret.name))
+		values[secret.name] = secret.value
+	}
+
+	for {
+		var selected string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title("Copy a secret to the clipboard?").
+					Options(options...).
+					Value(&selected),
+			),
+		).WithTheme(huh.ThemeCharm())
+		if err := form.Run(); err != nil {
+			if errors.Is(err, huh.ErrUserAborted) {
+				return nil
+			}
+			return err
+		}
+		if selected == "" {
+			return nil
+		}
+
+		if err := clipboard.WriteAll(values[selected]); err != nil {
+			fmt.Fprintf(out, "  %s Could not copy %s: %v\n", theme.Error("✗"), selected, err)
+			return nil
+		}
+		fmt.Fprintf(out, "  %s %s copied to the clipboard\n", theme.Success("✓"), selected)
+	}
+}
@@ -1,9 +1,16 @@
 package wizard
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/pem"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -18,11 +25,11 @@ func TestValidateInputsSuccess(t *testing.T) {
 		Workspace:        workspace,
 		Scheme:           "App",
 		BundleID:         "com.example.app",
-		TeamID:           "TEAMID123",
+		TeamID:           "TEAMID1234",
 		AppID:            "123456789",
-		ASCKeyID:         "KEYID123",
+		ASCKeyID:         "KEYID12345",
 		ASCIssuerID:      "00000000-0000-0000-0000-000000000000",
-		ASCPrivateKeyB64: base64.StdEncoding.EncodeToString([]byte("private-key")),
+		ASCPrivateKeyB64: testP8KeyB64(t, elliptic.P256()),
 	}
 
 	if err := validateInputs(inputs); err != nil {
@@ -35,9 +42,9 @@ func TestValidateInputsMissingWorkspace(t *testing.T) {
 		Workspace:        "/tmp/does-not-exist.xcworkspace",
 		Scheme:           "App",
 		BundleID:         "com.example.app",
-		TeamID:           "TEAMID123",
+		TeamID:           "TEAMID1234",
 		AppID:            "123456789",
-		ASCKeyID:         "KEYID123",
+		ASCKeyID:         "KEYID12345",
 		ASCIssuerID:      "00000000-0000-0000-0000-000000000000",
 		ASCPrivateKeyB64: "cHJpdmF0ZS1rZXk=",
 	}
@@ -59,9 +66,9 @@ func TestValidateInputsInvalidBase64(t *testing.T) {
 		Workspace:        workspace,
 		Scheme:           "App",
 		BundleID:         "com.example.app",
-		TeamID:           "TEAMID123",
+		TeamID:           "TEAMID1234",
 		AppID:            "123456789",
-		ASCKeyID:         "KEYID123",
+		ASCKeyID:         "KEYID12345",
 		ASCIssuerID:      "00000000-0000-0000-0000-000000000000",
 		ASCPrivateKeyB64: "not-base64@@",
 	}
@@ -97,3 +104,144 @@ func TestEncodeFileBase64(t *testing.T) {
 		t.Fatalf("encodeFileBase64() = %q, want %q", got, want)
 	}
 }
+
+func testP8PEM(t *testing.T) []byte {
+	t.Helper()
+	content, err := base64.StdEncoding.DecodeString(testP8KeyB64(t, elliptic.P256()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestNormalizePrivateKeyAcceptsCommonMistakes(t *testing.T) {
+	pemText := testP8PEM(t)
+	single := base64.StdEncoding.EncodeToString(pemText)
+
+	tests := []struct {
+		name     string
+		value    string
+		wantNote string
+	}{
+		{"single base64", single, ""},
+		{"wrapped base64", single[:40] + "\n" + single[40:], ""},
+		{"double base64", base64.StdEncoding.EncodeToString([]byte(single)), "base64-encoded twice"},
+		{"raw PEM", string(pemText), "raw PEM"},
+		{"raw PEM without line breaks", strings.ReplaceAll(string(pemText), "\n", " "), "raw PEM"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, note, err := normalizePrivateKey(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != single {
+				t.Errorf("expected canonical single base64, got %q", got)
+			}
+			if (tt.wantNote == "") != (note == "") || !strings.Contains(note, tt.wantNote) {
+				t.Errorf("unexpected note %q", note)
+			}
+		})
+	}
+}
+
+func TestNormalizePrivateKeyRejectsWrongKeys(t *testing.T) {
+	pemText := string(testP8PEM(t))
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaDER, _ := x509.MarshalPKCS8PrivateKey(rsaKey)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sec1DER, _ := x509.MarshalECPrivateKey(ecKey)
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"empty", "  ", "empty"},
+		{"not base64", "not-base64@@", "not valid base64"},
+		{"random file", encode("hello world"), "does not decode to PEM"},
+		{"truncated", encode(pemText[:len(pemText)-30]), "truncated"},
+		{"corrupted body", encode(strings.Replace(pemText, "\n", "\n!!", 1)), "not valid base64"},
+		{"certificate", encode(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}}))), "certificate"},
+		{"SEC1 key", encode(string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1DER}))), "SEC1"},
+		{"RSA key", encode(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rsaDER}))), "RSA"},
+		{"bad DER", encode(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{1, 2, 3}}))), "PKCS#8"},
+		{"P-384", testP8KeyB64(t, elliptic.P384()), "P-256"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := normalizePrivateKey(tt.value)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestFieldValidators(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(string) error
+		value    string
+		wantErr  string
+	}{
+		{"issuer ok", validateIssuerID, "69a6de70-03db-47e3-e053-5b8c7c11a4d1", ""},
+		{"issuer typo", validateIssuerID, "69a6de70-03db-47e3-e053-5b8c7c11a4d", "UUID"},
+		{"issuer blank", validateIssuerID, " ", "required"},
+		{"key ok", validateKeyID, "2X9R4HXF34", ""},
+		{"key short", validateKeyID, "KEYID123", "10 uppercase"},
+		{"team ok", validateTeamID, "ABCDE12345", ""},
+		{"team lowercase", validateTeamID, "abcde12345", "must be uppercase (ABCDE12345)"},
+		{"team symbols", validateTeamID, "ABCDE_1234", "10 uppercase"},
+		{"bundle ok", validateBundleID, "com.example.my-app", ""},
+		{"bundle single label", validateBundleID, "myapp", "reverse-DNS"},
+		{"bundle empty label", validateBundleID, "com..app", "reverse-DNS"},
+		{"bundle underscore", validateBundleID, "com.example.my_app", "reverse-DNS"},
+		{"bundle wildcard", validateBundleID, "com.example.*", "wildcard"},
+		{"app ok", validateAppID, "1234567890", ""},
+		{"app not numeric", validateAppID, "com.example.app", "numeric"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate(tt.value)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateInputsRejectsMalformedTeamID(t *testing.T) {
+	workspace := filepath.Join(t.TempDir(), "App.xcworkspace")
+	if err := os.Mkdir(workspace, 0o755); err != nil {
+		t.Fatalf("mkdir workspace: %v", err)
+	}
+
+	inputs := Inputs{
+		Workspace:        workspace,
+		Scheme:           "App",
+		BundleID:         "com.example.app",
+		TeamID:           "abcde12345",
+		AppID:            "123456789",
+		ASCKeyID:         "KEYID12345",
+		ASCIssuerID:      "00000000-0000-0000-0000-000000000000",
+		ASCPrivateKeyB64: testP8KeyB64(t, elliptic.P256()),
+	}
+	if err := validateInputs(inputs); err == nil || !strings.Contains(err.Error(), "Apple Team ID must be uppercase") {
+		t.Fatalf("expected team ID format error, got %v", err)
+	}
+}
@@ -4,26 +4,125 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
-	"text/template"
+	"strings"
 )
 
+// Defaults applied by GenerateWorkflow when WorkflowOptions leaves them blank.
+const (
+	DefaultConfiguration         = "Release"
+	DefaultRunnerLabel           = "macos-latest"
+	DefaultActionRef             = "v0"
+	DefaultArtifactName          = "ios-ipa"
+	DefaultArtifactRetentionDays = 7
+)
+
+// Workflow layouts. Jobs run on separate runners, so the two-job layout hands
+// the IPA from archive to upload through an artifact; the single-job layout
+// archives and uploads on one runner.
+const (
+	LayoutTwoJobs   = "two-jobs"
+	LayoutSingleJob = "single-job"
+)
+
+// WorkflowOptions shape the generated release workflow.
+type WorkflowOptions struct {
+	TemplatePath          string // user-supplied template; blank renders the built-in one
+	Kind                  ProjectKind
+	Container             string // .xcworkspace or .xcodeproj path
+	Scheme                string
+	BundleID              string
+	TeamID                string
+	AppID                 string
+	GitHubRepo            string
+	Configuration         string   // Xcode build configuration
+	RunnerLabels          []string // runs-on; several labels select a self-hosted runner
+	XcodeVersion          string   // selected with setup-xcode when set, e.g. "16.2"
+	ActionRef             string   // tag, branch or commit SHA of the releasekit-ios actions
+	XcodebuildExtraArgs   string   // appended to the archive step's xcodebuild call
+	Layout                string   // LayoutTwoJobs or LayoutSingleJob
+	ArtifactName          string
+	ArtifactRetentionDays int
+	ExportOptionsPath     string
+}
+
+// WorkflowOptions returns the workflow options recorded in inputs.
+func (i Inputs) WorkflowOptions() WorkflowOptions {
+	return WorkflowOptions{
+		TemplatePath:          i.WorkflowTemplate,
+		Kind:                  i.Kind(),
+		Container:             i.Workspace,
+		Scheme:                i.Scheme,
+		BundleID:              i.BundleID,
+		TeamID:                i.TeamID,
+		AppID:                 i.AppID,
+		GitHubRepo:            i.GitHubRepo,
+		Configuration:         i.Configuration,
+		RunnerLabels:          ParseRunnerLabels(i.RunnerLabel),
+		XcodeVersion:          i.XcodeVersion,
+		ActionRef:             i.ActionRef,
+		XcodebuildExtraArgs:   i.XcodebuildExtraArgs,
+		Layout:                i.WorkflowLayout,
+		ArtifactName:          i.ArtifactName,
+		ArtifactRetentionDays: i.ArtifactRetentionDays,
+		ExportOptionsPath:     i.ExportOptionsPath,
+	}
+}
+
+// ParseRunnerLabels splits a comma-separated runner label list, e.g.
+// "self-hosted, macOS, ARM64".
+func ParseRunnerLabels(s string) []string {
+	var labels []string
+	for _, label := range strings.Split(s, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
 // DefaultWorkflowPath returns the conventional path for the release workflow.
 func DefaultWorkflowPath() string {
 	return ".github/workflows/release.yml"
 }
 
-// GenerateWorkflow renders the release workflow YAML from the provided inputs.
-// Template uses [[ ]] delimiters to avoid collision with GitHub Actions ${{ }} syntax.
-func GenerateWorkflow(inputs Inputs) (string, error) {
-	// Note: delimiters are [[ ]] — NOT {{ }} — so that GitHub Actions ${{ secrets.X }}
-	// syntax is passed through verbatim and not interpreted by text/template.
-	tmpl, err := template.New("workflow").Delims("[[", "]]").Parse(workflowTemplate)
+// GenerateWorkflow renders the release workflow YAML from opts, using the
+// template at opts.TemplatePath when set. Templates use [[ ]] delimiters to
+// avoid collision with GitHub Actions ${{ }} syntax and are rendered with a
+// WorkflowData.
+func GenerateWorkflow(opts WorkflowOptions) (string, error) {
+	tmpl, err := parseWorkflowTemplate(opts.TemplatePath)
 	if err != nil {
 		return "", err
 	}
 
+	if opts.Kind == "" {
+		opts.Kind = ProjectKindForPath(opts.Container)
+	}
+	if opts.Configuration == "" {
+		opts.Configuration = DefaultConfiguration
+	}
+	if len(opts.RunnerLabels) == 0 {
+		opts.RunnerLabels = []string{DefaultRunnerLabel}
+	}
+	if opts.ActionRef == "" {
+		opts.ActionRef = DefaultActionRef
+	}
+	if opts.Layout == "" {
+		opts.Layout = LayoutTwoJobs
+	}
+	if opts.ArtifactName == "" {
+		opts.ArtifactName = DefaultArtifactName
+	}
+	if opts.ArtifactRetentionDays == 0 {
+		opts.ArtifactRetentionDays = DefaultArtifactRetentionDays
+	}
+
+	if err := validateWorkflowOptions(opts); err != nil {
+		return "", err
+	}
+
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, inputs); err != nil {
+	if err := tmpl.Execute(&buf, newWorkflowData(opts)); err != nil {
 		return "", err
 	}
 	return buf.String(), nil
@@ -37,7 +136,55 @@ func WriteWorkflow(path, content string) error {
 	return os.WriteFile(path, []byte(content), 0644)
 }
 
-// workflowTemplate is the GitHub Actions workflow template.
+// workflowSteps defines the steps shared by the built-in template and
+// available to user templates: "xcode", "archive" and "upload". Each renders
+// from a newline, indented as an item of a job's steps list.
+const workflowSteps = `[[define "archive"]]
+      - name: Archive
+        id: archive
+        uses: [[.Action "archive"]]
+        with:
+          [[.Kind]]: [[.Container]]
+          scheme: [[.Scheme]]
+          configuration: [[.Configuration]]
+[[- if .ExportOptionsPath]]
+          export_options_plist: [[.ExportOptionsPath]]
+[[- end]]
+[[- if .XcodebuildExtraArgs]]
+          xcodebuild_extra_args: [[quote .XcodebuildExtraArgs]]
+[[- end]]
+          bundle_id: ${{ vars.BUNDLE_ID }}
+          asc_team_id: ${{ vars.ASC_TEAM_ID }}
+          asc_key_id: ${{ secrets.ASC_KEY_ID }}
+          asc_issuer_id: ${{ secrets.ASC_ISSUER_ID }}
+          asc_private_key_b64: ${{ secrets.ASC_PRIVATE_KEY_B64 }}
+[[- end]]
+[[- define "upload"]]
+      - name: Upload
+        uses: [[.Action "upload"]]
+        with:
+[[- if .SingleJob]]
+          ipa_path: ${{ steps.archive.outputs.ipa_path }}
+[[- else]]
+          artifact_name: [[.ArtifactName]]
+[[- end]]
+          app_id: ${{ vars.ASC_APP_ID }}
+          asc_key_id: ${{ secrets.ASC_KEY_ID }}
+          asc_issuer_id: ${{ secrets.ASC_ISSUER_ID }}
+          asc_private_key_b64: ${{ secrets.ASC_PRIVATE_KEY_B64 }}
+[[- end]]
+[[- define "xcode"]]
+[[- if .XcodeVersion]]
+
+      - name: Select Xcode
+        uses: maxim-lobanov/setup-xcode@v1
+        with:
+          xcode-version: [[quote .XcodeVersion]]
+[[- end]]
+[[- end]]
+`
+
+// workflowTemplate is the built-in GitHub Actions workflow template.
 // Uses [[ ]] Go template delimiters so ${{ }} GitHub Actions expressions are untouched.
 const workflowTemplate = `name: Release iOS App
 
@@ -48,33 +195,43 @@ on:
       - 'v*'
 
 jobs:
+[[- if .SingleJob]]
+  release:
+    name: Archive and Upload
+    runs-on: [[runsOn .RunnerLabels]]
+
+    steps:
+      - uses: actions/checkout@v4
+[[- template "xcode" .]]
+
+      - name: Setup ASC
+        uses: rudrankriyam/setup-asc@v1
+[[template "archive" .]]
+[[template "upload" .]]
+[[- else]]
   archive:
     name: Archive
-    runs-on: macos-latest
-    outputs:
-      ipa-path: ${{ steps.archive.outputs.ipa_path }}
+    runs-on: [[runsOn .RunnerLabels]]
 
     steps:
       - uses: actions/checkout@v4
+[[- template "xcode" .]]
 
       - name: Setup ASC
         uses: rudrankriyam/setup-asc@v1
+[[template "archive" .]]
 
-      - name: Archive
-        id: archive
-        uses: vinceglb/releasekit-ios/actions/archive@v0
+      - name: Upload IPA artifact
+        uses: actions/upload-artifact@v4
         with:
-          workspace: [[.Workspace]]
-          scheme: [[.Scheme]]
-          bundle-id: ${{ vars.BUNDLE_ID }}
-          team-id: ${{ vars.ASC_TEAM_ID }}
-          asc-key-id: ${{ secrets.ASC_KEY_ID }}
-          asc-issuer-id: ${{ secrets.ASC_ISSUER_ID }}
-          asc-private-key-b64: ${{ secrets.ASC_PRIVATE_KEY_B64 }}
+          name: [[.ArtifactName]]
+          path: ${{ steps.archive.outputs.ipa_path }}
+          retention-days: [[.ArtifactRetentionDays]]
+          if-no-files-found: error
 
   upload:
     name: Upload
-    runs-on: macos-latest
+    runs-on: [[runsOn .RunnerLabels]]
     needs: archive
 
     steps:
@@ -82,13 +239,6 @@ jobs:
 
       - name: Setup ASC
         uses: rudrankriyam/setup-asc@v1
-
-      - name: Upload
-        uses: vinceglb/releasekit-ios/actions/upload@v0
-        with:
-          ipa-path: ${{ needs.archive.outputs.ipa-path }}
-          app-id: ${{ vars.ASC_APP_ID }}
-          asc-key-id: ${{ secrets.ASC_KEY_ID }}
-          asc-issuer-id: ${{ secrets.ASC_ISSUER_ID }}
-          asc-private-key-b64: ${{ secrets.ASC_PRIVATE_KEY_B64 }}
+[[template "upload" .]]
+[[- end]]
 `
@@ -6,12 +6,12 @@ import (
 )
 
 func TestGenerateWorkflowContainsWorkspaceAndScheme(t *testing.T) {
-	inputs := Inputs{
-		Workspace: "MyApp.xcworkspace",
+	opts := WorkflowOptions{
+		Container: "MyApp.xcworkspace",
 		Scheme:    "MyApp",
 	}
 
-	content, err := GenerateWorkflow(inputs)
+	content, err := GenerateWorkflow(opts)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -25,12 +25,12 @@ func TestGenerateWorkflowContainsWorkspaceAndScheme(t *testing.T) {
 }
 
 func TestGenerateWorkflowPreservesGitHubSyntax(t *testing.T) {
-	inputs := Inputs{
-		Workspace: "App.xcworkspace",
+	opts := WorkflowOptions{
+		Container: "App.xcworkspace",
 		Scheme:    "App",
 	}
 
-	content, err := GenerateWorkflow(inputs)
+	content, err := GenerateWorkflow(opts)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -52,12 +52,12 @@ func TestGenerateWorkflowPreservesGitHubSyntax(t *testing.T) {
 }
 
 func TestGenerateWorkflowContainsPinnedActions(t *testing.T) {
-	inputs := Inputs{
-		Workspace: "App.xcworkspace",
+	opts := WorkflowOptions{
+		Container: "App.xcworkspace",
 		Scheme:    "App",
 	}
 
-	content, err := GenerateWorkflow(inputs)
+	content, err := GenerateWorkflow(opts)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -71,8 +71,8 @@ func TestGenerateWorkflowContainsPinnedActions(t *testing.T) {
 }
 
 func TestGenerateWorkflowTriggersOnTag(t *testing.T) {
-	inputs := Inputs{Workspace: "App.xcworkspace", Scheme: "App"}
-	content, err := GenerateWorkflow(inputs)
+	opts := WorkflowOptions{Container: "App.xcworkspace", Scheme: "App"}
+	content, err := GenerateWorkflow(opts)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -87,3 +87,184 @@ func TestDefaultWorkflowPath(t *testing.T) {
 		t.Errorf("unexpected default workflow path: %q", path)
 	}
 }
+
+func TestGenerateWorkflowDefaultsRunnerAndConfiguration(t *testing.T) {
+	content, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcworkspace", Scheme: "App"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(content, "runs-on: macos-latest") {
+		t.Errorf("expected default runner label in output")
+	}
+	if !strings.Contains(content, "configuration: Release") {
+		t.Errorf("expected default configuration in output")
+	}
+}
+
+func TestGenerateWorkflowUsesRunnerAndConfiguration(t *testing.T) {
+	opts := WorkflowOptions{Container: "App.xcworkspace", Scheme: "App", RunnerLabels: []string{"macos-15"}, Configuration: "Staging"}
+	content, err := GenerateWorkflow(opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(content, "macos-latest") || strings.Count(content, "runs-on: macos-15") != 2 {
+		t.Errorf("expected both jobs to use macos-15, got:\n%s", content)
+	}
+	if !strings.Contains(content, "configuration: Staging") {
+		t.Errorf("expected configuration Staging in output")
+	}
+}
+
+func TestGenerateWorkflowStandaloneProject(t *testing.T) {
+	content, err := GenerateWorkflow(WorkflowOptions{Container: "MyApp.xcodeproj", Kind: KindProject, Scheme: "MyApp"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(content, "project: MyApp.xcodeproj") {
+		t.Errorf("expected project input in output, got:\n%s", content)
+	}
+	if strings.Contains(content, "workspace:") {
+		t.Errorf("expected no workspace input, got:\n%s", content)
+	}
+}
+
+func TestGenerateWorkflowPassesExportOptionsPlist(t *testing.T) {
+	opts := WorkflowOptions{Container: "App.xcworkspace", Scheme: "App"}
+	content, err := GenerateWorkflow(opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(content, "export_options_plist") {
+		t.Errorf("expected no export_options_plist by default:\n%s", content)
+	}
+
+	opts.ExportOptionsPath = "ci/ExportOptions.plist"
+	content, err = GenerateWorkflow(opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(content, "          export_options_plist: ci/ExportOptions.plist\n") {
+		t.Errorf("expected export_options_plist input:\n%s", content)
+	}
+}
+
+func TestGenerateWorkflowHandsOffIPAThroughArtifact(t *testing.T) {
+	content, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcworkspace", Scheme: "App", ArtifactName: "app-ipa", ArtifactRetentionDays: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"uses: actions/upload-artifact@v4",
+		"          name: app-ipa\n          path: ${{ steps.archive.outputs.ipa_path }}\n          retention-days: 3\n",
+		"          artifact_name: app-ipa\n",
+		"    needs: archive\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in output:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "needs.archive.outputs") {
+		t.Errorf("upload job must not read a file path from the archive job:\n%s", content)
+	}
+}
+
+func TestGenerateWorkflowSingleJobLayout(t *testing.T) {
+	content, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcworkspace", Scheme: "App", Layout: LayoutSingleJob})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(content, "          ipa_path: ${{ steps.archive.outputs.ipa_path }}\n") {
+		t.Errorf("expected upload step to read the archive step output:\n%s", content)
+	}
+	for _, unwanted := range []string{"upload-artifact", "artifact_name", "needs:"} {
+		if strings.Contains(content, unwanted) {
+			t.Errorf("unexpected %q in single-job workflow:\n%s", unwanted, content)
+		}
+	}
+}
+
+func TestGenerateWorkflowMatchesActionInputs(t *testing.T) {
+	cases := map[string]WorkflowOptions{
+		"workspace":      {Container: "App.xcworkspace", Scheme: "App"},
+		"project":        {Container: "App.xcodeproj", Kind: KindProject, Scheme: "App"},
+		"export options": {Container: "App.xcworkspace", Scheme: "App", ExportOptionsPath: "ci/ExportOptions.plist"},
+		"single job":     {Container: "App.xcworkspace", Scheme: "App", Layout: LayoutSingleJob},
+		"extra args":     {Container: "App.xcworkspace", Scheme: "App", XcodebuildExtraArgs: "-quiet"},
+	}
+	for name, opts := range cases {
+		content, err := GenerateWorkflow(opts)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		issues, err := ValidateWorkflow(content)
+		if err != nil {
+			t.Fatalf("%s: generated workflow is not valid YAML: %v", name, err)
+		}
+		for _, issue := range issues {
+			t.Errorf("%s: %s", name, issue)
+		}
+	}
+}
+
+func TestGenerateWorkflowSelfHostedRunner(t *testing.T) {
+	content, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcworkspace", Scheme: "App", RunnerLabels: ParseRunnerLabels("self-hosted, macOS, ARM64")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Count(content, "runs-on: [self-hosted, macOS, ARM64]\n"); got != 2 {
+		t.Errorf("expected both jobs on the self-hosted runner, got %d:\n%s", got, content)
+	}
+}
+
+func TestGenerateWorkflowSelectsXcode(t *testing.T) {
+	opts := WorkflowOptions{Container: "App.xcworkspace", Scheme: "App"}
+	content, err := GenerateWorkflow(opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(content, "setup-xcode") {
+		t.Errorf("expected no Xcode selection by default:\n%s", content)
+	}
+
+	opts.XcodeVersion = "16.2"
+	content, err = GenerateWorkflow(opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "      - uses: actions/checkout@v4\n\n      - name: Select Xcode\n        uses: maxim-lobanov/setup-xcode@v1\n        with:\n          xcode-version: \"16.2\"\n\n      - name: Setup ASC\n"
+	if got := strings.Count(content, want); got != 1 {
+		t.Errorf("expected one Xcode step in the archive job, got %d:\n%s", got, content)
+	}
+}
+
+func TestGenerateWorkflowPinsActionRef(t *testing.T) {
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	content, err := GenerateWorkflow(WorkflowOptions{Container: "App.xcworkspace", Scheme: "App", ActionRef: sha, XcodebuildExtraArgs: `-skipMacroValidation OTHER_SWIFT_FLAGS="-D CI"`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"uses: vinceglb/releasekit-ios/actions/archive@" + sha + "\n",
+		"uses: vinceglb/releasekit-ios/actions/upload@" + sha + "\n",
+		`          xcodebuild_extra_args: "-skipMacroValidation OTHER_SWIFT_FLAGS=\"-D CI\""` + "\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in output:\n%s", want, content)
+		}
+	}
+	if issues, err := ValidateWorkflow(content); err != nil || len(issues) > 0 {
+		t.Errorf("expected a valid workflow, got %v %v", issues, err)
+	}
+}
+
+func TestGenerateWorkflowRejectsInvalidOptions(t *testing.T) {
+	for name, opts := range map[string]WorkflowOptions{
+		"runner label":  {RunnerLabels: []string{"macos 15"}},
+		"action ref":    {ActionRef: "v0 --evil"},
+		"xcode version": {XcodeVersion: "16.2\n"},
+	} {
+		if _, err := GenerateWorkflow(opts); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
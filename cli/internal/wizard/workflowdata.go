@@ -0,0 +1,119 @@
+package wizard
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// WorkflowData is what a workflow template is rendered with, for the built-in
+// template and user-supplied ones alike. Its fields, methods and the functions
+// in workflowFuncs are the documented template interface: fields may be
+// added, but existing ones keep their name and meaning.
+type WorkflowData struct {
+	// Kind is "workspace" or "project", the archive action input that
+	// takes Container.
+	Kind ProjectKind
+	// Container is the path of the .xcworkspace or .xcodeproj.
+	Container string
+	Scheme    string
+	// Configuration is the Xcode build configuration; never blank.
+	Configuration string
+
+	// BundleID, TeamID, AppID and GitHubRepo ("owner/repo") come from the
+	// project config and may be blank. The built-in template reads the app
+	// values from repository variables instead, so that they can change
+	// without regenerating the workflow.
+	BundleID   string
+	TeamID     string
+	AppID      string
+	GitHubRepo string
+
+	// RunnerLabels holds at least one runs-on label; see runsOn.
+	RunnerLabels []string
+	// XcodeVersion is blank when the runner's default Xcode is used.
+	XcodeVersion string
+	// ActionRef is the tag, branch or commit SHA of the releasekit-ios
+	// actions; see Action.
+	ActionRef           string
+	XcodebuildExtraArgs string
+	ExportOptionsPath   string
+
+	// Layout is LayoutTwoJobs or LayoutSingleJob. In the two-job layout the
+	// IPA travels in the ArtifactName artifact, kept ArtifactRetentionDays.
+	Layout                string
+	ArtifactName          string
+	ArtifactRetentionDays int
+}
+
+func newWorkflowData(opts WorkflowOptions) WorkflowData {
+	return WorkflowData{
+		Kind:                  opts.Kind,
+		Container:             opts.Container,
+		Scheme:                opts.Scheme,
+		Configuration:         opts.Configuration,
+		BundleID:              opts.BundleID,
+		TeamID:                opts.TeamID,
+		AppID:                 opts.AppID,
+		GitHubRepo:            opts.GitHubRepo,
+		RunnerLabels:          opts.RunnerLabels,
+		XcodeVersion:          opts.XcodeVersion,
+		ActionRef:             opts.ActionRef,
+		XcodebuildExtraArgs:   opts.XcodebuildExtraArgs,
+		ExportOptionsPath:     opts.ExportOptionsPath,
+		Layout:                opts.Layout,
+		ArtifactName:          opts.ArtifactName,
+		ArtifactRetentionDays: opts.ArtifactRetentionDays,
+	}
+}
+
+// Action returns the uses: value of one of this repository's actions,
+// e.g. [[.Action "archive"]] -> vinceglb/releasekit-ios/actions/archive@v0.
+func (d WorkflowData) Action(name string) string {
+	return actionPrefix + name + "@" + d.ActionRef
+}
+
+// SingleJob reports whether archive and upload run in one job.
+func (d WorkflowData) SingleJob() bool {
+	return d.Layout == LayoutSingleJob
+}
+
+var workflowFuncs = template.FuncMap{
+	// quote renders s as a double-quoted YAML scalar.
+	"quote": strconv.Quote,
+	// runsOn renders one label as a plain scalar and several as a flow sequence.
+	"runsOn": func(labels []string) string {
+		if len(labels) == 1 {
+			return labels[0]
+		}
+		return "[" + strings.Join(labels, ", ") + "]"
+	},
+	// secrets and vars render GitHub Actions expressions, e.g.
+	// [[secrets "ASC_KEY_ID"]] -> ${{ secrets.ASC_KEY_ID }}.
+	"secrets": func(name string) string { return "${{ secrets." + name + " }}" },
+	"vars":    func(name string) string { return "${{ vars." + name + " }}" },
+}
+
+// parseWorkflowTemplate parses the template at path, or the built-in one when
+// path is blank, alongside the shared step definitions. A user template is
+// named after its path, so parse and execution errors read
+// "template: path:line: ...".
+func parseWorkflowTemplate(path string) (*template.Template, error) {
+	// Note: delimiters are [[ ]] — NOT {{ }} — so that GitHub Actions ${{ secrets.X }}
+	// syntax is passed through verbatim and not interpreted by text/template.
+	steps, err := template.New("steps").Delims("[[", "]]").Funcs(workflowFuncs).Parse(workflowSteps)
+	if err != nil {
+		return nil, err
+	}
+	name, body := "workflow", workflowTemplate
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read workflow template: %w", err)
+		}
+		name, body = path, string(content)
+	}
+	return steps.New(name).Parse(body)
+}
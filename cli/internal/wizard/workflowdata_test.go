@@ -0,0 +1,106 @@
+package wizard
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTemplate(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "release.yml.tmpl")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	return path
+}
+
+func TestGenerateWorkflowFromUserTemplate(t *testing.T) {
+	path := writeTemplate(t, `name: Release [[.Scheme]]
+
+on: push
+
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+
+  release:
+    needs: lint
+    runs-on: [[runsOn .RunnerLabels]]
+    env:
+      BUNDLE_ID: [[.BundleID]]
+      TOKEN: [[secrets "GH_TOKEN"]]
+    steps:
+      - uses: actions/checkout@v4
+[[- template "xcode" .]]
+[[template "archive" .]]
+[[template "upload" .]]
+`)
+	content, err := GenerateWorkflow(WorkflowOptions{
+		TemplatePath: path,
+		Container:    "App.xcworkspace",
+		Scheme:       "App",
+		BundleID:     "com.example.app",
+		RunnerLabels: []string{"self-hosted", "macOS"},
+		XcodeVersion: "16.2",
+		Layout:       LayoutSingleJob,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"name: Release App\n",
+		"    runs-on: [self-hosted, macOS]\n",
+		"      BUNDLE_ID: com.example.app\n",
+		"      TOKEN: ${{ secrets.GH_TOKEN }}\n",
+		`          xcode-version: "16.2"`,
+		"        uses: vinceglb/releasekit-ios/actions/archive@v0\n",
+		"          ipa_path: ${{ steps.archive.outputs.ipa_path }}\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in output:\n%s", want, content)
+		}
+	}
+	if issues, err := ValidateWorkflow(content); err != nil || len(issues) > 0 {
+		t.Errorf("expected a valid workflow, got %v %v", issues, err)
+	}
+}
+
+func TestGenerateWorkflowTemplateErrorsIncludeLine(t *testing.T) {
+	cases := map[string]struct {
+		template string
+		want     string
+	}{
+		"parse":   {"name: x\njobs:\n  a: [[end]]\n", ":3: unexpected [[end]]"},
+		"execute": {"name: x\n\njobs: [[.Workspace]]\n", ":3:8: executing"},
+		"func":    {"name: x\n[[env \"HOME\"]]\n", `:2: function "env" not defined`},
+	}
+	for name, tc := range cases {
+		path := writeTemplate(t, tc.template)
+		_, err := GenerateWorkflow(WorkflowOptions{TemplatePath: path, Container: "App.xcworkspace", Scheme: "App"})
+		if err == nil {
+			t.Errorf("%s: expected error", name)
+			continue
+		}
+		if !strings.Contains(err.Error(), path+tc.want) {
+			t.Errorf("%s: expected %q in %q", name, path+tc.want, err)
+		}
+	}
+}
+
+func TestGenerateWorkflowMissingTemplate(t *testing.T) {
+	_, err := GenerateWorkflow(WorkflowOptions{TemplatePath: filepath.Join(t.TempDir(), "gone.tmpl")})
+	if err == nil || !strings.Contains(err.Error(), "could not read workflow template") {
+		t.Errorf("expected read error, got %v", err)
+	}
+}
+
+func TestWorkflowDataAction(t *testing.T) {
+	data := WorkflowData{ActionRef: "main"}
+	if got := data.Action("archive"); got != "vinceglb/releasekit-ios/actions/archive@main" {
+		t.Errorf("Action() = %q", got)
+	}
+}
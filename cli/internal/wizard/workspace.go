@@ -0,0 +1,113 @@
+package wizard
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// workspaceItem is a <FileRef> or <Group> element of contents.xcworkspacedata.
+type workspaceItem struct {
+	XMLName  xml.Name
+	Location string          `xml:"location,attr"`
+	Items    []workspaceItem `xml:",any"`
+}
+
+// WorkspaceProjects reads the workspace's contents.xcworkspacedata and returns
+// the .xcodeproj paths it references, resolving group:, container:, absolute:
+// and self: locations. Projects that do not exist on disk are skipped.
+func WorkspaceProjects(workspacePath string) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(workspacePath, "contents.xcworkspacedata"))
+	if err != nil {
+		return nil, err
+	}
+	var root workspaceItem
+	if err := xml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("invalid contents.xcworkspacedata: %w", err)
+	}
+
+	container := filepath.Dir(workspacePath)
+	var projects []string
+	var walk func(items []workspaceItem, groupDir string)
+	walk = func(items []workspaceItem, groupDir string) {
+		for _, item := range items {
+			path, ok := resolveWorkspaceLocation(item.Location, groupDir, container, workspacePath)
+			switch item.XMLName.Local {
+			case "Group":
+				if !ok {
+					path = groupDir
+				}
+				walk(item.Items, path)
+			case "FileRef":
+				if ok && strings.HasSuffix(path, ".xcodeproj") && !containsPath(projects, path) {
+					if info, statErr := os.Stat(path); statErr == nil && info.IsDir() {
+						projects = append(projects, path)
+					}
+				}
+			}
+		}
+	}
+	walk(root.Items, container)
+	return projects, nil
+}
+
+// resolveWorkspaceLocation turns a "<kind>:<path>" workspace location into a
+// file path. group: is relative to the enclosing group, container: to the
+// directory holding the workspace, and self: is the project that embeds the
+// workspace (X.xcodeproj/project.xcworkspace).
+func resolveWorkspaceLocation(location, groupDir, container, workspacePath string) (string, bool) {
+	kind, path, ok := strings.Cut(location, ":")
+	if !ok {
+		return "", false
+	}
+	switch kind {
+	case "group":
+		return filepath.Join(groupDir, path), true
+	case "container":
+		return filepath.Join(container, path), true
+	case "absolute":
+		return filepath.Clean(path), true
+	case "self":
+		if project := filepath.Dir(workspacePath); strings.HasSuffix(project, ".xcodeproj") {
+			return project, true
+		}
+	}
+	return "", false
+}
+
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// workspaceProjectFiles returns the project.pbxproj files of the projects the
+// workspace references. When the workspace data cannot be read it falls back
+// to every project under the workspace directory. A standalone .xcodeproj
+// yields its own project.pbxproj.
+func workspaceProjectFiles(workspacePath string) []string {
+	if ProjectKindForPath(workspacePath) == KindProject {
+		path := filepath.Join(workspacePath, "project.pbxproj")
+		if fileExists(path) {
+			return []string{path}
+		}
+		return nil
+	}
+	projects, err := WorkspaceProjects(workspacePath)
+	if err != nil {
+		return findPbxprojFiles(filepath.Dir(workspacePath))
+	}
+	var files []string
+	for _, project := range projects {
+		path := filepath.Join(project, "project.pbxproj")
+		if fileExists(path) {
+			files = append(files, path)
+		}
+	}
+	return files
+}
@@ -0,0 +1,108 @@
+package wizard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeWorkspace(t *testing.T, path, refs string) {
+	t.Helper()
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<Workspace
+   version = "1.0">
+` + refs + `
+</Workspace>
+`
+	if err := os.WriteFile(filepath.Join(path, "contents.xcworkspacedata"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mkdirs(t *testing.T, paths ...string) {
+	t.Helper()
+	for _, path := range paths {
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestWorkspaceProjectsResolvesLocations(t *testing.T) {
+	root := t.TempDir()
+	external := t.TempDir()
+	mkdirs(t,
+		filepath.Join(root, "ios", "App.xcodeproj"),
+		filepath.Join(root, "ios", "Pods", "Pods.xcodeproj"),
+		filepath.Join(root, "ios", "Modules", "Feature", "Feature.xcodeproj"),
+		filepath.Join(root, "Shared", "Shared.xcodeproj"),
+		filepath.Join(external, "Ext.xcodeproj"),
+	)
+	workspace := filepath.Join(root, "ios", "App.xcworkspace")
+	writeWorkspace(t, workspace, fmt.Sprintf(`
+   <FileRef location = "group:App.xcodeproj"></FileRef>
+   <FileRef location = "group:Pods/Pods.xcodeproj"></FileRef>
+   <Group location = "group:Modules" name = "Modules">
+      <FileRef location = "group:Feature/Feature.xcodeproj"></FileRef>
+   </Group>
+   <FileRef location = "container:../Shared/Shared.xcodeproj"></FileRef>
+   <FileRef location = "absolute:%s"></FileRef>
+   <FileRef location = "group:Missing.xcodeproj"></FileRef>
+   <FileRef location = "group:README.md"></FileRef>`, filepath.Join(external, "Ext.xcodeproj")))
+
+	projects, err := WorkspaceProjects(workspace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		filepath.Join(root, "ios", "App.xcodeproj"),
+		filepath.Join(root, "ios", "Pods", "Pods.xcodeproj"),
+		filepath.Join(root, "ios", "Modules", "Feature", "Feature.xcodeproj"),
+		filepath.Join(root, "Shared", "Shared.xcodeproj"),
+		filepath.Join(external, "Ext.xcodeproj"),
+	}
+	if fmt.Sprint(projects) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, projects)
+	}
+}
+
+func TestWorkspaceProjectsSelf(t *testing.T) {
+	project := filepath.Join(t.TempDir(), "App.xcodeproj")
+	workspace := filepath.Join(project, "project.xcworkspace")
+	writeWorkspace(t, workspace, `   <FileRef location = "self:"></FileRef>`)
+
+	projects, err := WorkspaceProjects(workspace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(projects) != 1 || projects[0] != project {
+		t.Errorf("expected %s, got %v", project, projects)
+	}
+}
+
+func TestDetectTeamIDIgnoresProjectsOutsideWorkspace(t *testing.T) {
+	root := t.TempDir()
+	writeFixtureProject(t, filepath.Join(root, "App"))
+
+	// A sample project next to the workspace that the workspace does not reference.
+	sample := filepath.Join(root, "Sample.xcodeproj")
+	mkdirs(t, sample)
+	if err := os.WriteFile(filepath.Join(sample, "project.pbxproj"), []byte("DEVELOPMENT_TEAM = SAMPLE0000;\nDEVELOPMENT_TEAM = SAMPLE0000;\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	workspace := filepath.Join(root, "App.xcworkspace")
+	writeWorkspace(t, workspace, `   <FileRef location = "group:App/App.xcodeproj"></FileRef>`)
+
+	teamID, err := DetectTeamID(workspace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if teamID != "ZYXWV98765" {
+		t.Errorf("expected team from the referenced project, got %q", teamID)
+	}
+}
@@ -5,7 +5,12 @@ SCRIPT_DIR="$(cd "$(dirname "$0")" && pwd)"
 # shellcheck source=scripts/lib/common.sh
 source "${SCRIPT_DIR}/lib/common.sh"
 
-require_non_empty "INPUT_WORKSPACE" "${INPUT_WORKSPACE:-}"
+if [[ -n "${INPUT_WORKSPACE:-}" && -n "${INPUT_PROJECT:-}" ]]; then
+  fail "Set either workspace or project, not both."
+fi
+if [[ -z "${INPUT_WORKSPACE:-}" && -z "${INPUT_PROJECT:-}" ]]; then
+  fail "Missing required input: workspace (or project for a standalone .xcodeproj)."
+fi
 require_non_empty "INPUT_SCHEME" "${INPUT_SCHEME:-}"
 require_non_empty "INPUT_BUNDLE_ID" "${INPUT_BUNDLE_ID:-}"
 require_non_empty "INPUT_ASC_KEY_ID" "${INPUT_ASC_KEY_ID:-}"
@@ -23,11 +28,21 @@ if ! command -v xcodebuild >/dev/null 2>&1; then
   fail "xcodebuild not found. Use a macOS runner with Xcode installed."
 fi
 
-if [[ ! -e "${INPUT_WORKSPACE}" ]]; then
-  fail "Workspace path not found: ${INPUT_WORKSPACE}"
+if [[ -n "${INPUT_WORKSPACE:-}" ]]; then
+  container_kind="workspace"
+  container_path="${INPUT_WORKSPACE}"
+  container_ext="xcworkspace"
+else
+  container_kind="project"
+  container_path="${INPUT_PROJECT}"
+  container_ext="xcodeproj"
 fi
-if [[ "${INPUT_WORKSPACE}" != *.xcworkspace && "${INPUT_WORKSPACE}" != *.xcworkspace/ ]]; then
-  echo "::warning::workspace does not end with .xcworkspace: ${INPUT_WORKSPACE}" >&2
+
+if [[ ! -e "${container_path}" ]]; then
+  fail "Path for ${container_kind} not found: ${container_path}"
+fi
+if [[ "${container_path}" != *."${container_ext}" && "${container_path}" != *."${container_ext}"/ ]]; then
+  echo "::warning::${container_kind} does not end with .${container_ext}: ${container_path}" >&2
 fi
 
 runner_temp="${RUNNER_TEMP:-/tmp}"
@@ -53,7 +68,13 @@ chmod 600 "${private_key_path}"
 mkdir -p "$(dirname "${archive_path}")"
 mkdir -p "${export_path}"
 
-cat > "${export_options_path}" <<PLIST
+if [[ -n "${INPUT_EXPORT_OPTIONS_PLIST:-}" ]]; then
+  if [[ ! -f "${INPUT_EXPORT_OPTIONS_PLIST}" ]]; then
+    fail "Export options plist not found: ${INPUT_EXPORT_OPTIONS_PLIST}"
+  fi
+  export_options_path="${INPUT_EXPORT_OPTIONS_PLIST}"
+else
+  cat > "${export_options_path}" <<PLIST
 <?xml version="1.0" encoding="UTF-8"?>
 <!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
 <plist version="1.0">
@@ -69,13 +90,14 @@ cat > "${export_options_path}" <<PLIST
 </dict>
 </plist>
 PLIST
+fi
 
-echo "Archiving scheme '${INPUT_SCHEME}' from workspace '${INPUT_WORKSPACE}'"
+echo "Archiving scheme '${INPUT_SCHEME}' from ${container_kind} '${container_path}'"
 if [[ -n "${INPUT_XCODEBUILD_EXTRA_ARGS:-}" ]]; then
   # shellcheck disable=SC2206
   extra_args=(${INPUT_XCODEBUILD_EXTRA_ARGS})
   xcodebuild archive \
-    -workspace "${INPUT_WORKSPACE}" \
+    "-${container_kind}" "${container_path}" \
     -scheme "${INPUT_SCHEME}" \
     -configuration "${INPUT_CONFIGURATION}" \
     -archivePath "${archive_path}" \
@@ -87,7 +109,7 @@ if [[ -n "${INPUT_XCODEBUILD_EXTRA_ARGS:-}" ]]; then
     "${extra_args[@]}"
 else
   xcodebuild archive \
-    -workspace "${INPUT_WORKSPACE}" \
+    "-${container_kind}" "${container_path}" \
     -scheme "${INPUT_SCHEME}" \
     -configuration "${INPUT_CONFIGURATION}" \
     -archivePath "${archive_path}" \